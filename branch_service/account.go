@@ -0,0 +1,165 @@
+package branch_service
+
+import (
+	"branch_service/branch"
+	"fmt"
+	"sort"
+)
+
+// Account is a single customer's account as replicated at this branch.
+type Account struct {
+	CustomerID int32
+	Balances   map[string]int64 // Minor units, per currency code
+}
+
+func newAccount(customerID int32, opening *branch.Money) *Account {
+	return &Account{
+		CustomerID: customerID,
+		Balances:   map[string]int64{opening.CurrencyCode: opening.Units},
+	}
+}
+
+// restoredAccount rebuilds an account saved in a snapshot or a store.
+func restoredAccount(saved *branch.AccountSnapshot) *Account {
+	acc := &Account{CustomerID: saved.CustomerId, Balances: make(map[string]int64)}
+	for _, balance := range saved.Balances {
+		acc.Balances[balance.CurrencyCode] = balance.Units
+	}
+	return acc
+}
+
+func (acc *Account) copy() *Account {
+	balances := make(map[string]int64, len(acc.Balances))
+	for currency, units := range acc.Balances {
+		balances[currency] = units
+	}
+	return &Account{CustomerID: acc.CustomerID, Balances: balances}
+}
+
+// account returns the account of the given customer as the store holds it,
+// or an account with the branch's opening balance if the customer has not
+// been seen yet. Changes to it take effect once the store records them. The
+// caller must hold s.mu.
+func (s *BranchServer) account(customerID int32) (*Account, error) {
+	acc, err := s.store.Account(customerID)
+	if err != nil {
+		return nil, s.storeFailed(err)
+	}
+	if acc == nil {
+		acc = newAccount(customerID, s.money(s.openingBalance))
+	}
+	return acc, nil
+}
+
+// balance returns the account's balance in one currency.
+func (acc *Account) balance(currency string) *branch.Money {
+	return &branch.Money{CurrencyCode: currency, Units: acc.Balances[currency]}
+}
+
+// balances lists the account's balance in every currency it holds, ordered
+// by currency code.
+func (acc *Account) balances() []*branch.Money {
+	var balances []*branch.Money
+	for currency := range acc.Balances {
+		balances = append(balances, acc.balance(currency))
+	}
+	sort.Slice(balances, func(i, j int) bool { return balances[i].CurrencyCode < balances[j].CurrencyCode })
+	return balances
+}
+
+// settle checks that the accounts can take a write made at this branch and
+// fills in the balances the write leaves them with, without applying it. The
+// caller must hold s.mu.
+func (s *BranchServer) settle(write *branch.WriteEvent) error {
+	acc, err := s.account(write.CustomerId)
+	if err != nil {
+		return err
+	}
+	from := write.Money.CurrencyCode
+	switch write.Kind {
+	case branch.WriteEvent_DEPOSIT:
+		balance, err := addUnits(acc.Balances[from], write.Money.Units)
+		if err != nil {
+			return err
+		}
+		write.Balance = &branch.Money{CurrencyCode: from, Units: balance}
+	case branch.WriteEvent_WITHDRAW, branch.WriteEvent_CONVERT, branch.WriteEvent_TRANSFER:
+		if acc.Balances[from] < write.Money.Units {
+			return fmt.Errorf("insufficient balance")
+		}
+		write.Balance = &branch.Money{CurrencyCode: from, Units: acc.Balances[from] - write.Money.Units}
+	}
+	switch write.Kind {
+	case branch.WriteEvent_CONVERT:
+		to := write.Converted.CurrencyCode
+		balance, err := addUnits(acc.Balances[to], write.Converted.Units)
+		if err != nil {
+			return err
+		}
+		write.ConvertedBalance = &branch.Money{CurrencyCode: to, Units: balance}
+	case branch.WriteEvent_TRANSFER:
+		target, err := s.account(write.TargetCustomerId)
+		if err != nil {
+			return err
+		}
+		if _, err := addUnits(target.Balances[from], write.Money.Units); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// move moves the money of a write event between the accounts it touches,
+// and returns the accounts it changed for the store to record. Writes made
+// at other branches were checked there, so move only refuses a write that
+// would overflow a balance. The caller must hold s.mu.
+func (s *BranchServer) move(write *branch.WriteEvent) ([]*Account, error) {
+	acc, err := s.account(write.CustomerId)
+	if err != nil {
+		return nil, err
+	}
+	from := write.Money.GetCurrencyCode()
+	switch write.Kind {
+	case branch.WriteEvent_DEPOSIT:
+		balance, err := addUnits(acc.Balances[from], write.Money.GetUnits())
+		if err != nil {
+			return nil, err
+		}
+		acc.Balances[from] = balance
+	case branch.WriteEvent_WITHDRAW:
+		balance, err := addUnits(acc.Balances[from], -write.Money.GetUnits())
+		if err != nil {
+			return nil, err
+		}
+		acc.Balances[from] = balance
+	case branch.WriteEvent_CONVERT:
+		to := write.Converted.GetCurrencyCode()
+		debited, err := addUnits(acc.Balances[from], -write.Money.GetUnits())
+		if err != nil {
+			return nil, err
+		}
+		credited, err := addUnits(acc.Balances[to], write.Converted.GetUnits())
+		if err != nil {
+			return nil, err
+		}
+		acc.Balances[from] = debited
+		acc.Balances[to] = credited
+	case branch.WriteEvent_TRANSFER:
+		target, err := s.account(write.TargetCustomerId)
+		if err != nil {
+			return nil, err
+		}
+		debited, err := addUnits(acc.Balances[from], -write.Money.GetUnits())
+		if err != nil {
+			return nil, err
+		}
+		credited, err := addUnits(target.Balances[from], write.Money.GetUnits())
+		if err != nil {
+			return nil, err
+		}
+		acc.Balances[from] = debited
+		target.Balances[from] = credited
+		return []*Account{acc, target}, nil
+	}
+	return []*Account{acc}, nil
+}
@@ -0,0 +1,137 @@
+package branch_service
+
+import (
+	"branch_service/branch"
+	"context"
+	"log"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// DefaultAntiEntropyInterval is how often a branch pulls the write events
+	// it is missing from its peers, unless the branch is configured otherwise.
+	DefaultAntiEntropyInterval = 5 * time.Second
+
+	// MaxSyncWrites is the most write events SyncWrites returns at once, which
+	// keeps its response well below gRPC's message size limit.
+	MaxSyncWrites = 1000
+)
+
+// SyncWrites returns the write events this branch has applied that are
+// missing from the caller's vector clock, in the order they were applied here,
+// a page at a time. A caller told there are more asks again with its vector
+// clock once it has applied the page.
+// The caller's vector clock tells this branch which writes it no longer needs
+// to keep for the caller. A caller missing writes this branch has compacted
+// out of its history must catch up from another peer, or join again with a
+// snapshot.
+func (s *BranchServer) SyncWrites(ctx context.Context, request *branch.SyncWritesRequest) (*branch.SyncWritesResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.peers[request.BranchId]; ok {
+		s.acked[request.BranchId] = VectorClock(request.Vector)
+	}
+	base, err := s.historyBase()
+	if err != nil {
+		return nil, err
+	}
+	if !VectorClock(request.Vector).Dominates(base.Vector) {
+		return nil, status.Errorf(codes.OutOfRange, "branch %d no longer keeps write events branch %d is missing", s.ID, request.BranchId)
+	}
+	limit := int(request.Limit)
+	if limit <= 0 || limit > MaxSyncWrites {
+		limit = MaxSyncWrites
+	}
+	writes, more, err := s.missingWrites(request.Vector, limit)
+	if err != nil {
+		return nil, err
+	}
+	return &branch.SyncWritesResponse{
+		Writes: writes,
+		More:   more,
+	}, nil
+}
+
+// runAntiEntropy periodically pulls missing write events from every peer, so
+// replicas converge even when propagation to them failed for a long time, and
+// then compacts the history. It runs until the branch stops.
+func (s *BranchServer) runAntiEntropy() {
+	ticker := time.NewTicker(s.AntiEntropyInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+		case <-s.stop:
+			return
+		}
+		s.syncWithPeers()
+
+		s.mu.Lock()
+		if err := s.compactHistory(); err != nil {
+			log.Printf("Branch %d failed to compact its history: %v", s.ID, err)
+		}
+		s.mu.Unlock()
+	}
+}
+
+// syncWithPeers asks each peer that is not believed dead in turn for the
+// write events this branch is missing and applies them.
+func (s *BranchServer) syncWithPeers() {
+	s.mu.Lock()
+	peers := s.peerClients()
+	for peerID := range peers {
+		if !s.peerUp(peerID) {
+			delete(peers, peerID)
+		}
+	}
+	s.mu.Unlock()
+
+	for peerID, client := range peers {
+		// Page through the writes as long as each page gets this branch further
+		for s.syncPage(peerID, client) {
+			select {
+			case <-s.stop:
+				return
+			default:
+			}
+		}
+	}
+}
+
+// syncPage asks a peer for a page of the write events this branch is missing
+// and applies them. It reports whether the peer has more, and applying the
+// page got this branch further.
+func (s *BranchServer) syncPage(peerID int32, client branch.BranchServiceClient) bool {
+	s.mu.Lock()
+	vector := s.vector.Copy()
+	s.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), s.PropagateTimeout)
+	response, err := client.SyncWrites(ctx, &branch.SyncWritesRequest{
+		BranchId: s.ID,
+		Vector:   vector,
+	})
+	cancel()
+	if err != nil {
+		log.Printf("Failed to sync write events with peer %d: %v", peerID, err)
+		return false
+	}
+
+	// The peer lists its writes in an order it applied them, so each one
+	// is either next in causal order here or was already applied
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, write := range response.Writes {
+		if !s.readyToApply(write.OriginBranch, write.Vector) {
+			continue
+		}
+		if err := s.applyWrite(write); err != nil {
+			log.Printf("Failed to apply write event %d of customer %d from peer %d: %v", write.WriteEventID, write.CustomerId, peerID, err)
+			break
+		}
+	}
+	return response.More && !VectorClock(vector).Dominates(s.vector)
+}
@@ -0,0 +1,80 @@
+package branch_service
+
+import (
+	"branch_service/branch"
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// QueryBalanceAt returns a balance as it was at an earlier point, by
+// replaying the account's writes from this branch's history. At a time, the
+// balance counts the writes their origin accepted up to then that this
+// branch has applied; writes still on their way to this branch are missing.
+// At a vector clock, it counts exactly the writes the clock covers, once this
+// branch has applied them, and returns the clock. Either way, the writes count
+// in the order this branch applied them. A point before the writes the branch
+// compacted out of its history is out of range.
+func (s *BranchServer) QueryBalanceAt(ctx context.Context, request *branch.QueryBalanceAtRequest) (*branch.QueryBalanceResponse, error) {
+
+	currency, err := s.currencyCode(request.CurrencyCode)
+	if err != nil {
+		return nil, err
+	}
+	if (request.Timestamp != 0) == (len(request.Vector) != 0) {
+		return nil, status.Errorf(codes.InvalidArgument, "a point-in-time query needs either a timestamp or a vector clock")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	include := func(write *branch.WriteEvent) bool {
+		return write.Timestamp <= request.Timestamp
+	}
+	if len(request.Vector) != 0 {
+		// Block until this branch has applied every write the vector covers
+		err := s.waitUntil(ctx, func() bool { return s.vector.Dominates(request.Vector) })
+		if err != nil {
+			return nil, err
+		}
+		include = func(write *branch.WriteEvent) bool {
+			return write.Vector[write.OriginBranch] <= request.Vector[write.OriginBranch]
+		}
+	}
+
+	base, err := s.historyBase()
+	if err != nil {
+		return nil, err
+	}
+	if len(request.Vector) != 0 && !VectorClock(request.Vector).Dominates(base.Vector) || request.Timestamp != 0 && request.Timestamp < base.Timestamp {
+		return nil, status.Errorf(codes.OutOfRange, "branch %d no longer keeps the writes before the point asked for", s.ID)
+	}
+
+	// The vector clock of the counted writes, across every account
+	vector := make(VectorClock)
+	vector.Merge(base.Vector)
+	err = s.store.History(func(write *branch.WriteEvent) error {
+		if include(write) && vector[write.OriginBranch] < write.Vector[write.OriginBranch] {
+			vector[write.OriginBranch] = write.Vector[write.OriginBranch]
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, s.storeFailed(err)
+	}
+
+	history, err := s.accountHistory(request.CustomerId)
+	if err != nil {
+		return nil, err
+	}
+	acc, err := history.replay(include, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &branch.QueryBalanceResponse{
+		Money:    acc.balance(currency),
+		Balances: acc.balances(),
+		Vector:   vector,
+	}, nil
+}
@@ -0,0 +1,178 @@
+package branch_service
+
+import (
+	"branch_service/branch"
+	"encoding/binary"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+	"google.golang.org/protobuf/proto"
+)
+
+var (
+	boltAccounts = []byte("accounts") // Customer ID to AccountSnapshot
+	boltHistory  = []byte("history")  // Sequence number to WriteEvent
+	boltMeta     = []byte("meta")     // The history base, under boltBaseKey
+
+	boltBaseKey = []byte("base")
+)
+
+// boltStore keeps the branch's state in a bbolt file. Every change is its own
+// transaction, synced to disk before it returns.
+type boltStore struct {
+	db *bolt.DB
+}
+
+// OpenBoltStore opens the bbolt store in the file at path, creating it if
+// needed.
+func OpenBoltStore(path string) (Store, error) {
+	db, err := bolt.Open(path, 0644, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, err
+	}
+	err = db.Update(func(tx *bolt.Tx) error {
+		for _, name := range [][]byte{boltAccounts, boltHistory, boltMeta} {
+			if _, err := tx.CreateBucketIfNotExists(name); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	return &boltStore{db: db}, nil
+}
+
+// boltKey encodes integers so that their keys sort in numeric order.
+func boltKey(values ...int64) []byte {
+	key := make([]byte, 8*len(values))
+	for i, value := range values {
+		binary.BigEndian.PutUint64(key[8*i:], uint64(value)^(1<<63))
+	}
+	return key
+}
+
+func (b *boltStore) Account(customerID int32) (*Account, error) {
+	var acc *Account
+	err := b.db.View(func(tx *bolt.Tx) error {
+		data := tx.Bucket(boltAccounts).Get(boltKey(int64(customerID)))
+		if data == nil {
+			return nil
+		}
+		var err error
+		acc, err = unmarshalAccount(data)
+		return err
+	})
+	return acc, err
+}
+
+func (b *boltStore) PutAccount(acc *Account) error {
+	return b.db.Update(func(tx *bolt.Tx) error {
+		return putBoltAccount(tx, acc)
+	})
+}
+
+func putBoltAccount(tx *bolt.Tx, acc *Account) error {
+	data, err := marshalAccount(acc)
+	if err != nil {
+		return err
+	}
+	return tx.Bucket(boltAccounts).Put(boltKey(int64(acc.CustomerID)), data)
+}
+
+func (b *boltStore) Accounts(fn func(*Account) error) error {
+	return b.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(boltAccounts).ForEach(func(_, data []byte) error {
+			acc, err := unmarshalAccount(data)
+			if err != nil {
+				return err
+			}
+			return fn(acc)
+		})
+	})
+}
+
+func (b *boltStore) RecordApplied(write *branch.WriteEvent, changed ...*Account) error {
+	data, err := proto.Marshal(write)
+	if err != nil {
+		return err
+	}
+	return b.db.Update(func(tx *bolt.Tx) error {
+		for _, acc := range changed {
+			if err := putBoltAccount(tx, acc); err != nil {
+				return err
+			}
+		}
+		history := tx.Bucket(boltHistory)
+		seq, err := history.NextSequence()
+		if err != nil {
+			return err
+		}
+		return history.Put(boltKey(int64(seq)), data)
+	})
+}
+
+func (b *boltStore) History(fn func(*branch.WriteEvent) error) error {
+	return b.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(boltHistory).ForEach(func(_, data []byte) error {
+			write := &branch.WriteEvent{}
+			if err := proto.Unmarshal(data, write); err != nil {
+				return err
+			}
+			return fn(write)
+		})
+	})
+}
+
+func (b *boltStore) CompactHistory(n int, base *branch.HistoryBase) error {
+	data, err := proto.Marshal(base)
+	if err != nil {
+		return err
+	}
+	return b.db.Update(func(tx *bolt.Tx) error {
+		cursor := tx.Bucket(boltHistory).Cursor()
+		key, _ := cursor.First()
+		for i := 0; i < n && key != nil; i++ {
+			if err := cursor.Delete(); err != nil {
+				return err
+			}
+			key, _ = cursor.Next()
+		}
+		return tx.Bucket(boltMeta).Put(boltBaseKey, data)
+	})
+}
+
+func (b *boltStore) HistoryBase() (*branch.HistoryBase, error) {
+	var base *branch.HistoryBase
+	err := b.db.View(func(tx *bolt.Tx) error {
+		data := tx.Bucket(boltMeta).Get(boltBaseKey)
+		if data == nil {
+			return nil
+		}
+		base = &branch.HistoryBase{}
+		return proto.Unmarshal(data, base)
+	})
+	return base, err
+}
+
+func (b *boltStore) Close() error {
+	return b.db.Close()
+}
+
+// marshalAccount encodes an account the way snapshots store it.
+func marshalAccount(acc *Account) ([]byte, error) {
+	return proto.Marshal(&branch.AccountSnapshot{
+		CustomerId: acc.CustomerID,
+		Balances:   acc.balances(),
+	})
+}
+
+func unmarshalAccount(data []byte) (*Account, error) {
+	saved := &branch.AccountSnapshot{}
+	if err := proto.Unmarshal(data, saved); err != nil {
+		return nil, err
+	}
+	return restoredAccount(saved), nil
+}
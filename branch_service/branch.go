@@ -21,6 +21,9 @@ import (
 	"fmt"
 	"log"
 	"net"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"google.golang.org/grpc"
@@ -28,136 +31,497 @@ import (
 
 type BranchServer struct {
 	branch.UnimplementedBranchServiceServer
-	ID                  int32
-	Balance             float32 // Balance property for the branch server
-	port                int32
-	peers               map[int32]branch.BranchServiceClient
-	writeEventsReceived map[int32]bool
+	ID             int32
+	currency       string // Currency every account at this branch is kept in
+	openingBalance int64  // Minor units every account starts with at this branch
+	port           int32
+
+	// Address is where peers reach the branch, as it tells branches that
+	// join the cluster.
+	Address string
+
+	// MaxQueryWait bounds how long a request waits for the writes its
+	// customer's session depends on, even if the caller set no deadline.
+	MaxQueryWait time.Duration
+
+	// AntiEntropyInterval is how often the branch pulls missing write events
+	// from its peers.
+	AntiEntropyInterval time.Duration
+
+	// PropagationMode is how long Deposit and Withdraw wait for peers to
+	// receive the write, and PropagateTimeout is the deadline of each attempt
+	// to deliver it to one peer. Both must be set before peers are registered.
+	PropagationMode  branch.PropagationMode
+	PropagateTimeout time.Duration
+
+	// WithdrawalMode is whether withdrawals are checked at this branch or
+	// at the account's primary branch.
+	WithdrawalMode WithdrawalMode
+
+	// Rates quotes the exchange rates of ConvertCurrency. A branch without
+	// rates refuses conversions.
+	Rates RateProvider
+
+	// SnapshotInterval is how often a branch with a data directory saves a
+	// snapshot of its state and discards the log it covers.
+	SnapshotInterval time.Duration
+
+	// GossipInterval is how often the branch probes a peer to tell whether
+	// it is alive, and SuspectTimeout how long a peer that missed a probe
+	// has to refute it before the branch declares it dead.
+	GossipInterval time.Duration
+	SuspectTimeout time.Duration
+
+	// TLS is the certificate the branch serves with and presents to its
+	// peers, when branches authenticate each other with mutual TLS. Only
+	// branches may then call the methods between branches. The branch serves
+	// without TLS if it is nil.
+	TLS *TLSIdentity
+
+	// mu serializes every read and write of the branch state below, since
+	// gRPC runs each handler on its own goroutine.
+	mu          sync.Mutex
+	peers       map[int32]branch.BranchServiceClient
+	conns       map[int32]*grpc.ClientConn // Connections to the peers the branch dialed itself
+	members     map[int32]string           // Addresses of the peers, where known
+	health      map[int32]*memberHealth    // Whether each peer is believed alive
+	incarnation int64                      // Raised to refute that this branch is suspect or dead
+	left        bool                       // Set once the branch has left the cluster
+	outboxes    map[int32]*outbox          // Write events waiting to be delivered, per peer
+	store       Store                      // Customer accounts and the write events applied here, in order
+	vector      VectorClock                // Write events applied at this branch, per origin branch
+	acked       map[int32]VectorClock      // Write events each peer last said it has, through anti-entropy
+	dedup       *dedupWindow               // Results of recent write events, for answering retries
+	applied     chan struct{}              // Closed and replaced whenever a write event is applied
+	raft        *raftState                 // Set when the branch runs in Raft mode
+	wal         *wal                       // Set when the branch has a data directory
+	server      *grpc.Server               // Set once the branch has started
+	stopping    bool                       // Set once Stop was called
+
+	stop  chan struct{}  // Closed to end the branch's background loops
+	loops sync.WaitGroup // Background loops still running
 }
 
-func NewBranchServer(id int32, balance float32, port int32) *BranchServer {
+// NewBranchServer creates a branch whose accounts open with the given
+// balance, and are kept in its currency.
+func NewBranchServer(id int32, balance *branch.Money, port int32) *BranchServer {
+	currency := strings.ToUpper(balance.GetCurrencyCode())
+	if currency == "" {
+		currency = DefaultCurrency
+	}
 	return &BranchServer{
 		ID:                  id,
-		Balance:             balance,
+		currency:            currency,
+		openingBalance:      balance.GetUnits(),
 		port:                port,
+		Address:             fmt.Sprintf("localhost:%d", port),
 		peers:               make(map[int32]branch.BranchServiceClient),
-		writeEventsReceived: make(map[int32]bool),
+		conns:               make(map[int32]*grpc.ClientConn),
+		members:             make(map[int32]string),
+		health:              make(map[int32]*memberHealth),
+		outboxes:            make(map[int32]*outbox),
+		store:               NewMemoryStore(),
+		vector:              make(VectorClock),
+		acked:               make(map[int32]VectorClock),
+		dedup:               newDedupWindow(DefaultDedupWindow),
+		applied:             make(chan struct{}),
+		MaxQueryWait:        DefaultMaxQueryWait,
+		AntiEntropyInterval: DefaultAntiEntropyInterval,
+		PropagationMode:     branch.PropagationMode_SYNC_ALL,
+		PropagateTimeout:    DefaultPropagateTimeout,
+		SnapshotInterval:    DefaultSnapshotInterval,
+		GossipInterval:      DefaultGossipInterval,
+		SuspectTimeout:      DefaultSuspectTimeout,
 	}
 }
 
-func (s *BranchServer) AddEventID(id int32) {
-	s.writeEventsReceived[id] = true
-}
-
-func (s *BranchServer) IsEventIDExists(id int32) bool {
-	_, exists := s.writeEventsReceived[id]
-	return exists
-}
 func (s *BranchServer) QueryBalance(ctx context.Context, request *branch.QueryBalanceRequest) (*branch.QueryBalanceResponse, error) {
 
-	var lastWriteEventID int32 = request.LastWriteEventID
+	currency, err := s.currencyCode(request.CurrencyCode)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.raft != nil {
+		return s.raftQueryBalance(ctx, request, currency)
+	}
 
-	if lastWriteEventID == -1 {
-		// Return the current balance, since it's first query
-		// represented by EventID = -1
-		return &branch.QueryBalanceResponse{
-			Balance: s.Balance,
-		}, nil
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Block until this branch has seen what the customer's session requires
+	err = s.awaitRead(ctx, request.Session, request.Guarantees)
+	if err != nil {
+		return nil, err
 	}
-	for !s.IsEventIDExists(lastWriteEventID) {
-		time.Sleep(100 * time.Millisecond) // Wait for a short duration
+	acc, err := s.account(request.CustomerId)
+	if err != nil {
+		return nil, err
 	}
 
 	// Return the current balance
 	return &branch.QueryBalanceResponse{
-		Balance: s.Balance,
+		Money:    acc.balance(currency),
+		Balances: acc.balances(),
+		Vector:   s.vector.Copy(),
 	}, nil
 
 }
 
 func (s *BranchServer) Deposit(ctx context.Context, request *branch.DepositRequest) (*branch.DepositResponse, error) {
 
-	// Add the deposited amount to the balance
-	s.Balance += request.Amount
-	s.AddEventID(request.WriteEventID)
-	for peerID, client := range s.peers {
-		response, err := client.PropagateDeposit(context.Background(), &branch.PropagateDepositRequest{
-			Balance:      request.Amount,
-			WriteEventID: request.WriteEventID,
-		})
-		if err != nil || !response.Success {
-			log.Printf("Failed to propagate withdrawal to peer %d: %v", peerID, err)
+	money, err := s.amount(request.Money)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.raft != nil {
+		return s.raftDeposit(ctx, request, money)
+	}
+
+	s.mu.Lock()
+	if err := s.checkMember(); err != nil {
+		s.mu.Unlock()
+		return nil, err
+	}
+
+	// Block until this branch has seen what the customer's session requires
+	if err := s.awaitWrite(ctx, request.Session, request.Guarantees); err != nil {
+		s.mu.Unlock()
+		return nil, err
+	}
+
+	// A retried deposit gets the response it got the first time
+	if result, ok := s.replayed(request.CustomerId, request.WriteEventID); ok {
+		s.mu.Unlock()
+		if result.Forgotten {
+			return nil, errForgotten(request.CustomerId, request.WriteEventID)
 		}
+		return &branch.DepositResponse{
+			Balance:         result.Balance,
+			Vector:          result.Vector,
+			PropagationMode: s.PropagationMode,
+		}, nil
 	}
+
+	// Add the deposited amount to the balance
+	write := &branch.WriteEvent{
+		Kind:         branch.WriteEvent_DEPOSIT,
+		CustomerId:   request.CustomerId,
+		WriteEventID: request.WriteEventID,
+		Money:        money,
+		OriginBranch: s.ID,
+	}
+	if err := s.settle(write); err != nil {
+		s.mu.Unlock()
+		return nil, err
+	}
+	s.stamp(write)
+	if err := s.applyWrite(write); err != nil {
+		s.mu.Unlock()
+		return nil, err
+	}
+	done, peers := s.enqueue(write)
+	s.mu.Unlock()
+
+	acked := s.awaitDelivery(write, done, peers)
 	// Return the updated balance
 	return &branch.DepositResponse{
-		NewBalance: s.Balance,
+		Balance:         write.Balance,
+		Vector:          write.Vector,
+		PropagationMode: s.PropagationMode,
+		PeersAcked:      acked,
 	}, nil
 }
 
 func (s *BranchServer) Withdraw(ctx context.Context, request *branch.WithdrawRequest) (*branch.WithdrawResponse, error) {
 
-	// Check if there's enough balance to withdraw
-	if s.Balance < request.Amount {
-		return nil, fmt.Errorf("insufficient balance")
+	money, err := s.amount(request.Money)
+	if err != nil {
+		return nil, err
 	}
 
-	// Deduct the amount from the balance
-	s.Balance -= request.Amount
-	s.AddEventID(request.WriteEventID)
-	for peerID, client := range s.peers {
-		response, err := client.PropagateWithdraw(context.Background(), &branch.PropagateWithdrawRequest{
-			Balance:      request.Amount,
-			WriteEventID: request.WriteEventID,
-		})
-		if err != nil || !response.Success {
-			log.Printf("Failed to propagate withdrawal to peer %d: %v", peerID, err)
+	if s.raft != nil {
+		return s.raftWithdraw(ctx, request, money)
+	}
+
+	// Let the account's primary branch decide if withdrawals are coordinated
+	if response, forwarded, err := s.forwardWithdrawal(ctx, request); forwarded {
+		return response, err
+	}
+
+	s.mu.Lock()
+	if err := s.checkMember(); err != nil {
+		s.mu.Unlock()
+		return nil, err
+	}
+
+	// Block until this branch has seen what the customer's session requires
+	if err := s.awaitWrite(ctx, request.Session, request.Guarantees); err != nil {
+		s.mu.Unlock()
+		return nil, err
+	}
+
+	// A retried withdrawal gets the response it got the first time
+	if result, ok := s.replayed(request.CustomerId, request.WriteEventID); ok {
+		s.mu.Unlock()
+		if result.Forgotten {
+			return nil, errForgotten(request.CustomerId, request.WriteEventID)
 		}
+		return &branch.WithdrawResponse{
+			Balance:         result.Balance,
+			Vector:          result.Vector,
+			PropagationMode: s.PropagationMode,
+		}, nil
+	}
+
+	// Check if there's enough balance to withdraw, and deduct the amount
+	write := &branch.WriteEvent{
+		Kind:         branch.WriteEvent_WITHDRAW,
+		CustomerId:   request.CustomerId,
+		WriteEventID: request.WriteEventID,
+		Money:        money,
+		OriginBranch: s.ID,
 	}
+	if err := s.settle(write); err != nil {
+		s.mu.Unlock()
+		return nil, err
+	}
+	s.stamp(write)
+	if err := s.applyWrite(write); err != nil {
+		s.mu.Unlock()
+		return nil, err
+	}
+	done, peers := s.enqueue(write)
+	s.mu.Unlock()
+
+	acked := s.awaitDelivery(write, done, peers)
 	return &branch.WithdrawResponse{
-		NewBalance: s.Balance,
+		Balance:         write.Balance,
+		Vector:          write.Vector,
+		PropagationMode: s.PropagationMode,
+		PeersAcked:      acked,
 	}, nil
 }
 
-func (s *BranchServer) StartBranchServer() {
-	go func() {
-		listen, err := net.Listen("tcp", fmt.Sprintf(":%d", s.port))
-		if err != nil {
-			log.Fatalf("Failed to listen: %v", err)
+// StartBranchServer starts serving on the branch's port, and starts the
+// branch's background work. It returns the address the branch listens on
+// once it is listening, or the error that kept it from listening. A branch
+// created with port 0 listens on a free port, which Address then reports.
+func (s *BranchServer) StartBranchServer() (net.Addr, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.server != nil {
+		return nil, fmt.Errorf("branch %d was already started", s.ID)
+	}
+	listen, err := net.Listen("tcp", fmt.Sprintf(":%d", s.port))
+	if err != nil {
+		return nil, fmt.Errorf("branch %d failed to listen: %v", s.ID, err)
+	}
+	if s.port == 0 {
+		s.port = int32(listen.Addr().(*net.TCPAddr).Port)
+		if host, _, err := net.SplitHostPort(s.Address); err == nil {
+			s.Address = net.JoinHostPort(host, strconv.Itoa(int(s.port)))
 		}
+	}
 
-		server := grpc.NewServer()
-		branch.RegisterBranchServiceServer(server, s)
-
+	var options []grpc.ServerOption
+	if s.TLS != nil {
+		options = append(options, grpc.Creds(s.TLS.serverCredentials()), grpc.UnaryInterceptor(s.authorize), grpc.StreamInterceptor(s.authorizeStream))
+	}
+	server := grpc.NewServer(options...)
+	branch.RegisterBranchServiceServer(server, s)
+	s.server = server
+	s.stop = make(chan struct{})
+	go func() {
 		// log.Printf("Branch server is running on port %d...\n", s.port)
 		if err := server.Serve(listen); err != nil {
-			log.Fatalf("Failed to serve branch server: %v", err)
+			log.Printf("Branch %d stopped serving: %v", s.ID, err)
 		}
 	}()
+
+	if s.raft != nil {
+		s.background(s.runRaft)
+	} else {
+		s.background(s.runAntiEntropy)
+	}
+	if s.wal != nil {
+		s.background(s.runSnapshots)
+	}
+	s.background(s.runGossip)
+	return listen.Addr(), nil
 }
 
-// RegisterPeer registers a peer's gRPC client connection.
-func (s *BranchServer) RegisterPeer(peerID int32, client branch.BranchServiceClient) {
+// DefaultStopTimeout is how long the launcher lets a branch take to stop
+// gracefully before cutting it short.
+const DefaultStopTimeout = 10 * time.Second
+
+// Stop shuts the branch down gracefully. The branch stops accepting
+// requests, fails the ones waiting for writes to arrive and lets the others
+// finish, and then delivers the writes its peers have not received yet. It
+// then stops its background work, snapshots its state if it has a data
+// directory, and closes its store. If ctx ends first, the requests still
+// open are cancelled and undelivered writes stay queued in the history,
+// where peers pull them through anti-entropy once the branch is back. A
+// stopped branch cannot be started again.
+func (s *BranchServer) Stop(ctx context.Context) error {
+	s.mu.Lock()
+	server := s.server
+	if server == nil || s.stopping {
+		s.mu.Unlock()
+		return nil
+	}
+	s.stopping = true
+	// Wake requests waiting for writes, which now give up
+	s.notifyApplied()
+	s.mu.Unlock()
+
+	served := make(chan struct{})
+	go func() {
+		server.GracefulStop()
+		close(served)
+	}()
+	select {
+	case <-served:
+	case <-ctx.Done():
+		server.Stop()
+		<-served
+	}
+
+	// Deliver what the branch accepted before it stopped
+	s.mu.Lock()
+	outboxes := s.outboxList()
+	s.mu.Unlock()
+	err := awaitDrained(ctx, outboxes)
+
+	close(s.stop)
+	s.loops.Wait()
+	s.mu.Lock()
+	for _, o := range s.outboxes {
+		o.close()
+	}
+	for _, conn := range s.conns {
+		conn.Close()
+	}
+	s.mu.Unlock()
+
+	// Save the state, so the branch restarts from a snapshot
+	if s.wal != nil {
+		if snapshotErr := s.takeSnapshot(false); snapshotErr != nil && err == nil {
+			err = snapshotErr
+		}
+		if closeErr := s.wal.close(); closeErr != nil && err == nil {
+			err = closeErr
+		}
+	}
+	if closeErr := s.store.Close(); closeErr != nil && err == nil {
+		err = closeErr
+	}
+	log.Printf("Branch %d stopped", s.ID)
+	return err
+}
+
+// background runs one of the branch's loops until the branch stops.
+func (s *BranchServer) background(loop func()) {
+	s.loops.Add(1)
+	go func() {
+		defer s.loops.Done()
+		loop()
+	}()
+}
+
+// RegisterPeer registers a peer's gRPC client connection, and the address it
+// serves on if known.
+func (s *BranchServer) RegisterPeer(peerID int32, address string, client branch.BranchServiceClient) {
 
 	// Store the peer client in the peers map.
+	s.mu.Lock()
+	s.addPeer(peerID, address, client, nil)
+	s.mu.Unlock()
+}
+
+// addPeer registers a peer, replacing the client of a peer that registers
+// again. conn is the connection of the client if the branch dialed it, and
+// is closed when the peer is removed or replaced. A peer is believed alive
+// when it registers. The caller must hold s.mu.
+func (s *BranchServer) addPeer(peerID int32, address string, client branch.BranchServiceClient, conn *grpc.ClientConn) {
+	if old, ok := s.outboxes[peerID]; ok {
+		old.close()
+	}
+	if old, ok := s.conns[peerID]; ok {
+		old.Close()
+		delete(s.conns, peerID)
+	}
 	s.peers[peerID] = client
+	if conn != nil {
+		s.conns[peerID] = conn
+	}
+	s.outboxes[peerID] = newOutbox(peerID, client, s.PropagateTimeout)
+	if address != "" {
+		s.members[peerID] = address
+	}
+	health := &memberHealth{changed: time.Now()}
+	if old, ok := s.health[peerID]; ok {
+		health.incarnation = old.incarnation
+	}
+	s.health[peerID] = health
+}
+
+// peerClients returns a copy of the peers map so that propagation can run
+// without holding the lock. The caller must hold s.mu.
+func (s *BranchServer) peerClients() map[int32]branch.BranchServiceClient {
+	peers := make(map[int32]branch.BranchServiceClient, len(s.peers))
+	for peerID, client := range s.peers {
+		peers[peerID] = client
+	}
+	return peers
 }
 
-// UpdateBalance updates the balance of a specific branch in the data map.
+// PropagateWithdraw applies a withdrawal made at a peer branch to the customer's account.
 func (s *BranchServer) PropagateWithdraw(ctx context.Context, request *branch.PropagateWithdrawRequest) (*branch.PropagateWithdrawResponse, error) {
 
-	s.Balance -= request.Balance
-	s.AddEventID(request.WriteEventID)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Wait for the writes it depends on, and skip it if it was already applied
+	err := s.applyRemote(ctx, &branch.WriteEvent{
+		Kind:         branch.WriteEvent_WITHDRAW,
+		CustomerId:   request.CustomerId,
+		WriteEventID: request.WriteEventID,
+		Money:        request.Money,
+		OriginBranch: request.OriginBranch,
+		Vector:       request.Vector,
+		Balance:      request.Balance,
+		Timestamp:    request.Timestamp,
+	})
+	if err != nil {
+		return nil, err
+	}
 	return &branch.PropagateWithdrawResponse{
 		Success: true,
 	}, nil
 }
 
-// UpdateBalance updates the balance of a specific branch in the data map.
+// PropagateDeposit applies a deposit made at a peer branch to the customer's account.
 func (s *BranchServer) PropagateDeposit(ctx context.Context, request *branch.PropagateDepositRequest) (*branch.PropagateDepositResponse, error) {
 
-	s.Balance += request.Balance
-	s.AddEventID(request.WriteEventID)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Wait for the writes it depends on, and skip it if it was already applied
+	err := s.applyRemote(ctx, &branch.WriteEvent{
+		Kind:         branch.WriteEvent_DEPOSIT,
+		CustomerId:   request.CustomerId,
+		WriteEventID: request.WriteEventID,
+		Money:        request.Money,
+		OriginBranch: request.OriginBranch,
+		Vector:       request.Vector,
+		Balance:      request.Balance,
+		Timestamp:    request.Timestamp,
+	})
+	if err != nil {
+		return nil, err
+	}
 	return &branch.PropagateDepositResponse{
 		Success: true,
 	}, nil
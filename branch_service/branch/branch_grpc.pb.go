@@ -24,9 +24,23 @@ const _ = grpc.SupportPackageIsVersion7
 type BranchServiceClient interface {
 	Withdraw(ctx context.Context, in *WithdrawRequest, opts ...grpc.CallOption) (*WithdrawResponse, error)
 	QueryBalance(ctx context.Context, in *QueryBalanceRequest, opts ...grpc.CallOption) (*QueryBalanceResponse, error)
+	QueryBalanceAt(ctx context.Context, in *QueryBalanceAtRequest, opts ...grpc.CallOption) (*QueryBalanceResponse, error)
 	Deposit(ctx context.Context, in *DepositRequest, opts ...grpc.CallOption) (*DepositResponse, error)
 	PropagateWithdraw(ctx context.Context, in *PropagateWithdrawRequest, opts ...grpc.CallOption) (*PropagateWithdrawResponse, error)
 	PropagateDeposit(ctx context.Context, in *PropagateDepositRequest, opts ...grpc.CallOption) (*PropagateDepositResponse, error)
+	ConvertCurrency(ctx context.Context, in *ConvertCurrencyRequest, opts ...grpc.CallOption) (*ConvertCurrencyResponse, error)
+	PropagateConvert(ctx context.Context, in *PropagateConvertRequest, opts ...grpc.CallOption) (*PropagateConvertResponse, error)
+	Transfer(ctx context.Context, in *TransferRequest, opts ...grpc.CallOption) (*TransferResponse, error)
+	PropagateTransfer(ctx context.Context, in *PropagateTransferRequest, opts ...grpc.CallOption) (*PropagateTransferResponse, error)
+	GetStatement(ctx context.Context, in *StatementRequest, opts ...grpc.CallOption) (BranchService_GetStatementClient, error)
+	SyncWrites(ctx context.Context, in *SyncWritesRequest, opts ...grpc.CallOption) (*SyncWritesResponse, error)
+	JoinCluster(ctx context.Context, in *JoinClusterRequest, opts ...grpc.CallOption) (*JoinClusterResponse, error)
+	LeaveCluster(ctx context.Context, in *LeaveClusterRequest, opts ...grpc.CallOption) (*LeaveClusterResponse, error)
+	ListMembers(ctx context.Context, in *ListMembersRequest, opts ...grpc.CallOption) (*ListMembersResponse, error)
+	Ping(ctx context.Context, in *PingRequest, opts ...grpc.CallOption) (*PingResponse, error)
+	PingReq(ctx context.Context, in *PingReqRequest, opts ...grpc.CallOption) (*PingResponse, error)
+	RequestVote(ctx context.Context, in *RequestVoteRequest, opts ...grpc.CallOption) (*RequestVoteResponse, error)
+	AppendEntries(ctx context.Context, in *AppendEntriesRequest, opts ...grpc.CallOption) (*AppendEntriesResponse, error)
 }
 
 type branchServiceClient struct {
@@ -55,6 +69,15 @@ func (c *branchServiceClient) QueryBalance(ctx context.Context, in *QueryBalance
 	return out, nil
 }
 
+func (c *branchServiceClient) QueryBalanceAt(ctx context.Context, in *QueryBalanceAtRequest, opts ...grpc.CallOption) (*QueryBalanceResponse, error) {
+	out := new(QueryBalanceResponse)
+	err := c.cc.Invoke(ctx, "/main.BranchService/QueryBalanceAt", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *branchServiceClient) Deposit(ctx context.Context, in *DepositRequest, opts ...grpc.CallOption) (*DepositResponse, error) {
 	out := new(DepositResponse)
 	err := c.cc.Invoke(ctx, "/main.BranchService/Deposit", in, out, opts...)
@@ -82,15 +105,169 @@ func (c *branchServiceClient) PropagateDeposit(ctx context.Context, in *Propagat
 	return out, nil
 }
 
+func (c *branchServiceClient) ConvertCurrency(ctx context.Context, in *ConvertCurrencyRequest, opts ...grpc.CallOption) (*ConvertCurrencyResponse, error) {
+	out := new(ConvertCurrencyResponse)
+	err := c.cc.Invoke(ctx, "/main.BranchService/ConvertCurrency", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *branchServiceClient) PropagateConvert(ctx context.Context, in *PropagateConvertRequest, opts ...grpc.CallOption) (*PropagateConvertResponse, error) {
+	out := new(PropagateConvertResponse)
+	err := c.cc.Invoke(ctx, "/main.BranchService/PropagateConvert", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *branchServiceClient) Transfer(ctx context.Context, in *TransferRequest, opts ...grpc.CallOption) (*TransferResponse, error) {
+	out := new(TransferResponse)
+	err := c.cc.Invoke(ctx, "/main.BranchService/Transfer", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *branchServiceClient) PropagateTransfer(ctx context.Context, in *PropagateTransferRequest, opts ...grpc.CallOption) (*PropagateTransferResponse, error) {
+	out := new(PropagateTransferResponse)
+	err := c.cc.Invoke(ctx, "/main.BranchService/PropagateTransfer", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *branchServiceClient) GetStatement(ctx context.Context, in *StatementRequest, opts ...grpc.CallOption) (BranchService_GetStatementClient, error) {
+	stream, err := c.cc.NewStream(ctx, &BranchService_ServiceDesc.Streams[0], "/main.BranchService/GetStatement", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &branchServiceGetStatementClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type BranchService_GetStatementClient interface {
+	Recv() (*StatementEntry, error)
+	grpc.ClientStream
+}
+
+type branchServiceGetStatementClient struct {
+	grpc.ClientStream
+}
+
+func (x *branchServiceGetStatementClient) Recv() (*StatementEntry, error) {
+	m := new(StatementEntry)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *branchServiceClient) SyncWrites(ctx context.Context, in *SyncWritesRequest, opts ...grpc.CallOption) (*SyncWritesResponse, error) {
+	out := new(SyncWritesResponse)
+	err := c.cc.Invoke(ctx, "/main.BranchService/SyncWrites", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *branchServiceClient) JoinCluster(ctx context.Context, in *JoinClusterRequest, opts ...grpc.CallOption) (*JoinClusterResponse, error) {
+	out := new(JoinClusterResponse)
+	err := c.cc.Invoke(ctx, "/main.BranchService/JoinCluster", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *branchServiceClient) LeaveCluster(ctx context.Context, in *LeaveClusterRequest, opts ...grpc.CallOption) (*LeaveClusterResponse, error) {
+	out := new(LeaveClusterResponse)
+	err := c.cc.Invoke(ctx, "/main.BranchService/LeaveCluster", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *branchServiceClient) ListMembers(ctx context.Context, in *ListMembersRequest, opts ...grpc.CallOption) (*ListMembersResponse, error) {
+	out := new(ListMembersResponse)
+	err := c.cc.Invoke(ctx, "/main.BranchService/ListMembers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *branchServiceClient) Ping(ctx context.Context, in *PingRequest, opts ...grpc.CallOption) (*PingResponse, error) {
+	out := new(PingResponse)
+	err := c.cc.Invoke(ctx, "/main.BranchService/Ping", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *branchServiceClient) PingReq(ctx context.Context, in *PingReqRequest, opts ...grpc.CallOption) (*PingResponse, error) {
+	out := new(PingResponse)
+	err := c.cc.Invoke(ctx, "/main.BranchService/PingReq", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *branchServiceClient) RequestVote(ctx context.Context, in *RequestVoteRequest, opts ...grpc.CallOption) (*RequestVoteResponse, error) {
+	out := new(RequestVoteResponse)
+	err := c.cc.Invoke(ctx, "/main.BranchService/RequestVote", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *branchServiceClient) AppendEntries(ctx context.Context, in *AppendEntriesRequest, opts ...grpc.CallOption) (*AppendEntriesResponse, error) {
+	out := new(AppendEntriesResponse)
+	err := c.cc.Invoke(ctx, "/main.BranchService/AppendEntries", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // BranchServiceServer is the server API for BranchService service.
 // All implementations must embed UnimplementedBranchServiceServer
 // for forward compatibility
 type BranchServiceServer interface {
 	Withdraw(context.Context, *WithdrawRequest) (*WithdrawResponse, error)
 	QueryBalance(context.Context, *QueryBalanceRequest) (*QueryBalanceResponse, error)
+	QueryBalanceAt(context.Context, *QueryBalanceAtRequest) (*QueryBalanceResponse, error)
 	Deposit(context.Context, *DepositRequest) (*DepositResponse, error)
 	PropagateWithdraw(context.Context, *PropagateWithdrawRequest) (*PropagateWithdrawResponse, error)
 	PropagateDeposit(context.Context, *PropagateDepositRequest) (*PropagateDepositResponse, error)
+	ConvertCurrency(context.Context, *ConvertCurrencyRequest) (*ConvertCurrencyResponse, error)
+	PropagateConvert(context.Context, *PropagateConvertRequest) (*PropagateConvertResponse, error)
+	Transfer(context.Context, *TransferRequest) (*TransferResponse, error)
+	PropagateTransfer(context.Context, *PropagateTransferRequest) (*PropagateTransferResponse, error)
+	GetStatement(*StatementRequest, BranchService_GetStatementServer) error
+	SyncWrites(context.Context, *SyncWritesRequest) (*SyncWritesResponse, error)
+	JoinCluster(context.Context, *JoinClusterRequest) (*JoinClusterResponse, error)
+	LeaveCluster(context.Context, *LeaveClusterRequest) (*LeaveClusterResponse, error)
+	ListMembers(context.Context, *ListMembersRequest) (*ListMembersResponse, error)
+	Ping(context.Context, *PingRequest) (*PingResponse, error)
+	PingReq(context.Context, *PingReqRequest) (*PingResponse, error)
+	RequestVote(context.Context, *RequestVoteRequest) (*RequestVoteResponse, error)
+	AppendEntries(context.Context, *AppendEntriesRequest) (*AppendEntriesResponse, error)
 	mustEmbedUnimplementedBranchServiceServer()
 }
 
@@ -104,6 +281,9 @@ func (UnimplementedBranchServiceServer) Withdraw(context.Context, *WithdrawReque
 func (UnimplementedBranchServiceServer) QueryBalance(context.Context, *QueryBalanceRequest) (*QueryBalanceResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method QueryBalance not implemented")
 }
+func (UnimplementedBranchServiceServer) QueryBalanceAt(context.Context, *QueryBalanceAtRequest) (*QueryBalanceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QueryBalanceAt not implemented")
+}
 func (UnimplementedBranchServiceServer) Deposit(context.Context, *DepositRequest) (*DepositResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Deposit not implemented")
 }
@@ -113,6 +293,45 @@ func (UnimplementedBranchServiceServer) PropagateWithdraw(context.Context, *Prop
 func (UnimplementedBranchServiceServer) PropagateDeposit(context.Context, *PropagateDepositRequest) (*PropagateDepositResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method PropagateDeposit not implemented")
 }
+func (UnimplementedBranchServiceServer) ConvertCurrency(context.Context, *ConvertCurrencyRequest) (*ConvertCurrencyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConvertCurrency not implemented")
+}
+func (UnimplementedBranchServiceServer) PropagateConvert(context.Context, *PropagateConvertRequest) (*PropagateConvertResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PropagateConvert not implemented")
+}
+func (UnimplementedBranchServiceServer) Transfer(context.Context, *TransferRequest) (*TransferResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Transfer not implemented")
+}
+func (UnimplementedBranchServiceServer) PropagateTransfer(context.Context, *PropagateTransferRequest) (*PropagateTransferResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PropagateTransfer not implemented")
+}
+func (UnimplementedBranchServiceServer) GetStatement(*StatementRequest, BranchService_GetStatementServer) error {
+	return status.Errorf(codes.Unimplemented, "method GetStatement not implemented")
+}
+func (UnimplementedBranchServiceServer) SyncWrites(context.Context, *SyncWritesRequest) (*SyncWritesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SyncWrites not implemented")
+}
+func (UnimplementedBranchServiceServer) JoinCluster(context.Context, *JoinClusterRequest) (*JoinClusterResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method JoinCluster not implemented")
+}
+func (UnimplementedBranchServiceServer) LeaveCluster(context.Context, *LeaveClusterRequest) (*LeaveClusterResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LeaveCluster not implemented")
+}
+func (UnimplementedBranchServiceServer) ListMembers(context.Context, *ListMembersRequest) (*ListMembersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListMembers not implemented")
+}
+func (UnimplementedBranchServiceServer) Ping(context.Context, *PingRequest) (*PingResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Ping not implemented")
+}
+func (UnimplementedBranchServiceServer) PingReq(context.Context, *PingReqRequest) (*PingResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PingReq not implemented")
+}
+func (UnimplementedBranchServiceServer) RequestVote(context.Context, *RequestVoteRequest) (*RequestVoteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RequestVote not implemented")
+}
+func (UnimplementedBranchServiceServer) AppendEntries(context.Context, *AppendEntriesRequest) (*AppendEntriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AppendEntries not implemented")
+}
 func (UnimplementedBranchServiceServer) mustEmbedUnimplementedBranchServiceServer() {}
 
 // UnsafeBranchServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -162,6 +381,24 @@ func _BranchService_QueryBalance_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _BranchService_QueryBalanceAt_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryBalanceAtRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BranchServiceServer).QueryBalanceAt(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/main.BranchService/QueryBalanceAt",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BranchServiceServer).QueryBalanceAt(ctx, req.(*QueryBalanceAtRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _BranchService_Deposit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(DepositRequest)
 	if err := dec(in); err != nil {
@@ -216,6 +453,243 @@ func _BranchService_PropagateDeposit_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _BranchService_ConvertCurrency_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConvertCurrencyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BranchServiceServer).ConvertCurrency(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/main.BranchService/ConvertCurrency",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BranchServiceServer).ConvertCurrency(ctx, req.(*ConvertCurrencyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BranchService_PropagateConvert_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PropagateConvertRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BranchServiceServer).PropagateConvert(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/main.BranchService/PropagateConvert",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BranchServiceServer).PropagateConvert(ctx, req.(*PropagateConvertRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BranchService_Transfer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransferRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BranchServiceServer).Transfer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/main.BranchService/Transfer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BranchServiceServer).Transfer(ctx, req.(*TransferRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BranchService_PropagateTransfer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PropagateTransferRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BranchServiceServer).PropagateTransfer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/main.BranchService/PropagateTransfer",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BranchServiceServer).PropagateTransfer(ctx, req.(*PropagateTransferRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BranchService_GetStatement_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StatementRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BranchServiceServer).GetStatement(m, &branchServiceGetStatementServer{stream})
+}
+
+type BranchService_GetStatementServer interface {
+	Send(*StatementEntry) error
+	grpc.ServerStream
+}
+
+type branchServiceGetStatementServer struct {
+	grpc.ServerStream
+}
+
+func (x *branchServiceGetStatementServer) Send(m *StatementEntry) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _BranchService_SyncWrites_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SyncWritesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BranchServiceServer).SyncWrites(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/main.BranchService/SyncWrites",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BranchServiceServer).SyncWrites(ctx, req.(*SyncWritesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BranchService_JoinCluster_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(JoinClusterRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BranchServiceServer).JoinCluster(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/main.BranchService/JoinCluster",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BranchServiceServer).JoinCluster(ctx, req.(*JoinClusterRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BranchService_LeaveCluster_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LeaveClusterRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BranchServiceServer).LeaveCluster(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/main.BranchService/LeaveCluster",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BranchServiceServer).LeaveCluster(ctx, req.(*LeaveClusterRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BranchService_ListMembers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMembersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BranchServiceServer).ListMembers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/main.BranchService/ListMembers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BranchServiceServer).ListMembers(ctx, req.(*ListMembersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BranchService_Ping_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BranchServiceServer).Ping(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/main.BranchService/Ping",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BranchServiceServer).Ping(ctx, req.(*PingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BranchService_PingReq_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PingReqRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BranchServiceServer).PingReq(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/main.BranchService/PingReq",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BranchServiceServer).PingReq(ctx, req.(*PingReqRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BranchService_RequestVote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestVoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BranchServiceServer).RequestVote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/main.BranchService/RequestVote",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BranchServiceServer).RequestVote(ctx, req.(*RequestVoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BranchService_AppendEntries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AppendEntriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BranchServiceServer).AppendEntries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/main.BranchService/AppendEntries",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BranchServiceServer).AppendEntries(ctx, req.(*AppendEntriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // BranchService_ServiceDesc is the grpc.ServiceDesc for BranchService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -231,6 +705,10 @@ var BranchService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "QueryBalance",
 			Handler:    _BranchService_QueryBalance_Handler,
 		},
+		{
+			MethodName: "QueryBalanceAt",
+			Handler:    _BranchService_QueryBalanceAt_Handler,
+		},
 		{
 			MethodName: "Deposit",
 			Handler:    _BranchService_Deposit_Handler,
@@ -243,7 +721,61 @@ var BranchService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "PropagateDeposit",
 			Handler:    _BranchService_PropagateDeposit_Handler,
 		},
+		{
+			MethodName: "ConvertCurrency",
+			Handler:    _BranchService_ConvertCurrency_Handler,
+		},
+		{
+			MethodName: "PropagateConvert",
+			Handler:    _BranchService_PropagateConvert_Handler,
+		},
+		{
+			MethodName: "Transfer",
+			Handler:    _BranchService_Transfer_Handler,
+		},
+		{
+			MethodName: "PropagateTransfer",
+			Handler:    _BranchService_PropagateTransfer_Handler,
+		},
+		{
+			MethodName: "SyncWrites",
+			Handler:    _BranchService_SyncWrites_Handler,
+		},
+		{
+			MethodName: "JoinCluster",
+			Handler:    _BranchService_JoinCluster_Handler,
+		},
+		{
+			MethodName: "LeaveCluster",
+			Handler:    _BranchService_LeaveCluster_Handler,
+		},
+		{
+			MethodName: "ListMembers",
+			Handler:    _BranchService_ListMembers_Handler,
+		},
+		{
+			MethodName: "Ping",
+			Handler:    _BranchService_Ping_Handler,
+		},
+		{
+			MethodName: "PingReq",
+			Handler:    _BranchService_PingReq_Handler,
+		},
+		{
+			MethodName: "RequestVote",
+			Handler:    _BranchService_RequestVote_Handler,
+		},
+		{
+			MethodName: "AppendEntries",
+			Handler:    _BranchService_AppendEntries_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "GetStatement",
+			Handler:       _BranchService_GetStatement_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "branch.proto",
 }
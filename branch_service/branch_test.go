@@ -0,0 +1,211 @@
+package branch_service
+
+import (
+	"branch_service/branch"
+	"context"
+	"fmt"
+	"math/rand"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// startTestCluster starts n branches on free ports, with IDs from 1, that
+// are peers of each other, after configure has set each of them up. The
+// branches stop when the test ends. It returns the branches and a client of
+// each.
+func startTestCluster(t *testing.T, n int, opening int64, configure func(*BranchServer)) ([]*BranchServer, []branch.BranchServiceClient) {
+	t.Helper()
+	servers, clients := startTestBranches(t, n, opening, configure)
+	connectTestBranches(servers, clients)
+	return servers, clients
+}
+
+// startTestBranches starts n branches like startTestCluster, but without
+// making them peers.
+func startTestBranches(t *testing.T, n int, opening int64, configure func(*BranchServer)) ([]*BranchServer, []branch.BranchServiceClient) {
+	t.Helper()
+	servers := make([]*BranchServer, n)
+	clients := make([]branch.BranchServiceClient, n)
+	for i := range servers {
+		s := NewBranchServer(int32(i+1), &branch.Money{CurrencyCode: "USD", Units: opening}, 0)
+		if configure != nil {
+			configure(s)
+		}
+		addr, err := s.StartBranchServer()
+		if err != nil {
+			t.Fatalf("starting branch %d: %v", s.ID, err)
+		}
+		t.Cleanup(func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			s.Stop(ctx)
+		})
+		conn, err := grpc.Dial(addr.String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+		if err != nil {
+			t.Fatalf("dialing branch %d: %v", s.ID, err)
+		}
+		t.Cleanup(func() { conn.Close() })
+		servers[i] = s
+		clients[i] = branch.NewBranchServiceClient(conn)
+	}
+	return servers, clients
+}
+
+// connectTestBranches makes every branch a peer of every other.
+func connectTestBranches(servers []*BranchServer, clients []branch.BranchServiceClient) {
+	for i, s := range servers {
+		for j, peer := range servers {
+			if i != j {
+				s.RegisterPeer(peer.ID, peer.Address, clients[j])
+			}
+		}
+	}
+}
+
+// awaitBalances waits until every branch reports the expected balance of
+// every customer, and fails the test if they do not within the timeout.
+func awaitBalances(t *testing.T, clients []branch.BranchServiceClient, want map[int32]int64, timeout time.Duration) {
+	t.Helper()
+	deadline := time.Now().Add(timeout)
+	for {
+		mismatch := ""
+		for i, client := range clients {
+			for customerID, units := range want {
+				response, err := client.QueryBalance(context.Background(), &branch.QueryBalanceRequest{CustomerId: customerID})
+				if err != nil {
+					t.Fatalf("querying branch %d: %v", i+1, err)
+				}
+				if got := response.Money.GetUnits(); got != units && mismatch == "" {
+					mismatch = fmt.Sprintf("branch %d has %d for customer %d, want %d", i+1, got, customerID, units)
+				}
+			}
+		}
+		if mismatch == "" {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatal(mismatch)
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
+
+// TestConcurrentRPCs makes deposits, withdrawals and queries at every branch
+// of a cluster at once, while a branch outside the cluster propagates its own
+// deposits and withdrawals to all of them, and checks that every branch ends
+// up with every write applied exactly once. Run it with -race.
+func TestConcurrentRPCs(t *testing.T) {
+	const (
+		branches  = 3
+		customers = 5
+		opening   = 100
+		workers   = 4 // Per branch
+		requests  = 50
+		outsider  = 9 // Origin of the propagated writes
+	)
+	_, clients := startTestCluster(t, branches, opening, nil)
+	ctx := context.Background()
+
+	var eventIDs, balances [customers + 1]atomic.Int64
+	for customerID := 1; customerID <= customers; customerID++ {
+		balances[customerID].Store(opening)
+	}
+	nextEvent := func(customerID int32) int32 {
+		return int32(eventIDs[customerID].Add(1))
+	}
+	usdOf := func(units int64) *branch.Money {
+		return &branch.Money{CurrencyCode: "USD", Units: units}
+	}
+
+	var wg sync.WaitGroup
+	for b, client := range clients {
+		for w := 0; w < workers; w++ {
+			wg.Add(1)
+			go func(client branch.BranchServiceClient, seed int64) {
+				defer wg.Done()
+				r := rand.New(rand.NewSource(seed))
+				for i := 0; i < requests; i++ {
+					customerID := int32(1 + r.Intn(customers))
+					units := int64(1 + r.Intn(20))
+					switch r.Intn(3) {
+					case 0:
+						_, err := client.Deposit(ctx, &branch.DepositRequest{CustomerId: customerID, WriteEventID: nextEvent(customerID), Money: usdOf(units)})
+						if err != nil {
+							t.Errorf("deposit: %v", err)
+							return
+						}
+						balances[customerID].Add(units)
+					case 1:
+						// A withdrawal the balance cannot cover fails
+						_, err := client.Withdraw(ctx, &branch.WithdrawRequest{CustomerId: customerID, WriteEventID: nextEvent(customerID), Money: usdOf(units)})
+						if err == nil {
+							balances[customerID].Add(-units)
+						}
+					case 2:
+						if _, err := client.QueryBalance(ctx, &branch.QueryBalanceRequest{CustomerId: customerID}); err != nil {
+							t.Errorf("query: %v", err)
+							return
+						}
+					}
+				}
+			}(client, int64(b*workers+w))
+		}
+	}
+
+	// The outside branch numbers its writes, and sends each one to every
+	// branch at once, so the branches receive them out of order
+	var sequence atomic.Int64
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(seed int64) {
+			defer wg.Done()
+			r := rand.New(rand.NewSource(seed))
+			for i := 0; i < requests; i++ {
+				customerID := int32(1 + r.Intn(customers))
+				units := int64(1 + r.Intn(20))
+				deposit := r.Intn(2) == 0
+				eventID := nextEvent(customerID)
+				vector := map[int32]int64{outsider: sequence.Add(1)}
+				if deposit {
+					balances[customerID].Add(units)
+				} else {
+					balances[customerID].Add(-units)
+				}
+
+				var propagated sync.WaitGroup
+				for _, client := range clients {
+					propagated.Add(1)
+					go func(client branch.BranchServiceClient) {
+						defer propagated.Done()
+						var err error
+						if deposit {
+							_, err = client.PropagateDeposit(ctx, &branch.PropagateDepositRequest{CustomerId: customerID, WriteEventID: eventID, OriginBranch: outsider, Vector: vector, Money: usdOf(units)})
+						} else {
+							_, err = client.PropagateWithdraw(ctx, &branch.PropagateWithdrawRequest{CustomerId: customerID, WriteEventID: eventID, OriginBranch: outsider, Vector: vector, Money: usdOf(units)})
+						}
+						if err != nil {
+							t.Errorf("propagate: %v", err)
+						}
+					}(client)
+				}
+				propagated.Wait()
+			}
+		}(int64(1000 + w))
+	}
+
+	wg.Wait()
+	if t.Failed() {
+		return
+	}
+
+	want := make(map[int32]int64)
+	for customerID := int32(1); customerID <= customers; customerID++ {
+		want[customerID] = balances[customerID].Load()
+	}
+	awaitBalances(t, clients, want, 30*time.Second)
+}
@@ -0,0 +1,106 @@
+package branch_service
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultBasePort is the port branch 1 serves on when there is no cluster
+// config. Branch N serves on DefaultBasePort+N-1 on localhost.
+const DefaultBasePort = 8080
+
+// ClusterConfig lists the branches of a cluster and where each one serves,
+// so that branches and customers can run on separate hosts.
+type ClusterConfig struct {
+	Branches []ClusterBranch `json:"branches" yaml:"branches"`
+}
+
+// ClusterBranch is where one branch serves. The branch listens on Port on
+// every interface, and its peers and customers reach it at Host. A branch
+// with port 0 listens on a free port chosen when it starts, which others
+// only learn through a FileRegistry.
+type ClusterBranch struct {
+	ID   int32  `json:"id" yaml:"id"`
+	Host string `json:"host" yaml:"host"`
+	Port int32  `json:"port" yaml:"port"`
+}
+
+// Address is where peers and customers reach the branch.
+func (b ClusterBranch) Address() string {
+	return net.JoinHostPort(b.Host, strconv.Itoa(int(b.Port)))
+}
+
+// LoadClusterConfig reads a cluster config, as YAML if the file name ends
+// in .yaml or .yml and as JSON otherwise. A branch without a host is on
+// localhost.
+func LoadClusterConfig(path string) (*ClusterConfig, error) {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading cluster config: %v", err)
+	}
+	config := &ClusterConfig{}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(contents, config)
+	default:
+		err = json.Unmarshal(contents, config)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error parsing cluster config %s: %v", path, err)
+	}
+
+	seen := make(map[int32]bool)
+	for i := range config.Branches {
+		b := &config.Branches[i]
+		if b.ID <= 0 {
+			return nil, fmt.Errorf("cluster config %s lists a branch without a positive id", path)
+		}
+		if seen[b.ID] {
+			return nil, fmt.Errorf("cluster config %s lists branch %d twice", path, b.ID)
+		}
+		seen[b.ID] = true
+		if b.Port < 0 || b.Port > 65535 {
+			return nil, fmt.Errorf("cluster config %s gives branch %d no valid port", path, b.ID)
+		}
+		if b.Host == "" {
+			b.Host = "localhost"
+		}
+	}
+	sort.Slice(config.Branches, func(i, j int) bool { return config.Branches[i].ID < config.Branches[j].ID })
+	return config, nil
+}
+
+// DefaultClusterConfig places the given branches on localhost, each on
+// DefaultBasePort plus its ID minus one, as when every branch runs in one
+// process.
+func DefaultClusterConfig(ids []int32) *ClusterConfig {
+	config := &ClusterConfig{}
+	seen := make(map[int32]bool)
+	for _, id := range ids {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		config.Branches = append(config.Branches, ClusterBranch{ID: id, Host: "localhost", Port: DefaultBasePort + id - 1})
+	}
+	sort.Slice(config.Branches, func(i, j int) bool { return config.Branches[i].ID < config.Branches[j].ID })
+	return config
+}
+
+// Branch returns where the branch with the given ID serves.
+func (c *ClusterConfig) Branch(id int32) (ClusterBranch, error) {
+	for _, b := range c.Branches {
+		if b.ID == id {
+			return b, nil
+		}
+	}
+	return ClusterBranch{}, fmt.Errorf("branch %d is not in the cluster config", id)
+}
@@ -0,0 +1,129 @@
+package branch_service
+
+import (
+	"branch_service/branch"
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ConvertCurrency exchanges money the customer holds in one currency for
+// another currency, as a single write that debits one balance and credits
+// the other.
+func (s *BranchServer) ConvertCurrency(ctx context.Context, request *branch.ConvertCurrencyRequest) (*branch.ConvertCurrencyResponse, error) {
+
+	money, err := s.amount(request.Money)
+	if err != nil {
+		return nil, err
+	}
+	to, err := s.currencyCode(request.ToCurrency)
+	if err != nil {
+		return nil, err
+	}
+	if to == money.CurrencyCode {
+		return nil, status.Errorf(codes.InvalidArgument, "cannot convert %s to itself", to)
+	}
+
+	if s.raft != nil {
+		return s.raftConvert(ctx, request, money, to)
+	}
+
+	// A conversion takes money out of the account like a withdrawal, so the
+	// account's primary branch decides if withdrawals are coordinated
+	if response, forwarded, err := s.forwardConversion(ctx, request); forwarded {
+		return response, err
+	}
+
+	converted, err := s.quote(ctx, money, to)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	if err := s.checkMember(); err != nil {
+		s.mu.Unlock()
+		return nil, err
+	}
+
+	// Block until this branch has seen what the customer's session requires
+	if err := s.awaitWrite(ctx, request.Session, request.Guarantees); err != nil {
+		s.mu.Unlock()
+		return nil, err
+	}
+
+	// A retried conversion gets the response it got the first time, at the
+	// rate it was first quoted
+	if result, ok := s.replayed(request.CustomerId, request.WriteEventID); ok {
+		s.mu.Unlock()
+		if result.Forgotten {
+			return nil, errForgotten(request.CustomerId, request.WriteEventID)
+		}
+		return &branch.ConvertCurrencyResponse{
+			Balance:          result.Balance,
+			Converted:        result.Converted,
+			ConvertedBalance: result.ConvertedBalance,
+			Vector:           result.Vector,
+			PropagationMode:  s.PropagationMode,
+		}, nil
+	}
+
+	// Check if there's enough balance to convert, and move the money
+	write := &branch.WriteEvent{
+		Kind:         branch.WriteEvent_CONVERT,
+		CustomerId:   request.CustomerId,
+		WriteEventID: request.WriteEventID,
+		Money:        money,
+		Converted:    converted,
+		OriginBranch: s.ID,
+	}
+	if err := s.settle(write); err != nil {
+		s.mu.Unlock()
+		return nil, err
+	}
+	s.stamp(write)
+	if err := s.applyWrite(write); err != nil {
+		s.mu.Unlock()
+		return nil, err
+	}
+	done, peers := s.enqueue(write)
+	s.mu.Unlock()
+
+	acked := s.awaitDelivery(write, done, peers)
+	return &branch.ConvertCurrencyResponse{
+		Balance:          write.Balance,
+		Converted:        write.Converted,
+		ConvertedBalance: write.ConvertedBalance,
+		Vector:           write.Vector,
+		PropagationMode:  s.PropagationMode,
+		PeersAcked:       acked,
+	}, nil
+}
+
+// PropagateConvert applies a currency conversion made at a peer branch to the
+// customer's account, crediting the amount the peer quoted.
+func (s *BranchServer) PropagateConvert(ctx context.Context, request *branch.PropagateConvertRequest) (*branch.PropagateConvertResponse, error) {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Wait for the writes it depends on, and skip it if it was already applied
+	err := s.applyRemote(ctx, &branch.WriteEvent{
+		Kind:             branch.WriteEvent_CONVERT,
+		CustomerId:       request.CustomerId,
+		WriteEventID:     request.WriteEventID,
+		Money:            request.Money,
+		Converted:        request.Converted,
+		OriginBranch:     request.OriginBranch,
+		Vector:           request.Vector,
+		Balance:          request.Balance,
+		ConvertedBalance: request.ConvertedBalance,
+		Timestamp:        request.Timestamp,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &branch.PropagateConvertResponse{
+		Success: true,
+	}, nil
+}
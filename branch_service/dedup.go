@@ -0,0 +1,205 @@
+package branch_service
+
+import (
+	"branch_service/branch"
+	"sort"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// DefaultDedupWindow is how many recent write events a branch remembers the
+// results of, in order to answer a retried write the way it was answered the
+// first time.
+const DefaultDedupWindow = 10000
+
+// appliedTail is how many of a customer's applied event IDs above its
+// watermark a branch tracks one by one.
+const appliedTail = 1024
+
+// writeKey identifies a write event across every branch: event IDs are only
+// unique within one customer's events.
+type writeKey struct {
+	CustomerID int32
+	EventID    int32
+}
+
+// writeResult is what a write event returned when it was first applied, so a
+// retry can be answered with the same response.
+type writeResult struct {
+	Balance          *branch.Money
+	Converted        *branch.Money // Set for currency conversions
+	ConvertedBalance *branch.Money
+	Vector           map[int32]int64
+
+	// Forgotten is set for a write event that was applied too long ago for
+	// the window to still hold its result, or to tell whether it was applied.
+	Forgotten bool
+}
+
+func resultOf(write *branch.WriteEvent) writeResult {
+	return writeResult{
+		Balance:          write.Balance,
+		Converted:        write.Converted,
+		ConvertedBalance: write.ConvertedBalance,
+		Vector:           write.Vector,
+	}
+}
+
+// dedupWindow remembers the results of the most recent write events, forgetting
+// the oldest once it holds more than size of them. It keeps recognising the
+// write events whose results it forgot.
+type dedupWindow struct {
+	size    int
+	results map[writeKey]writeResult
+	order   []writeKey
+	applied map[int32]*appliedEvents // Event IDs applied, per customer
+}
+
+func newDedupWindow(size int) *dedupWindow {
+	return &dedupWindow{
+		size:    size,
+		results: make(map[writeKey]writeResult),
+		applied: make(map[int32]*appliedEvents),
+	}
+}
+
+// lookup returns the result of the write event if it was already applied.
+// The result is forgotten if the window no longer holds it, or if the event
+// is too old for the window to tell whether it was applied.
+func (d *dedupWindow) lookup(key writeKey) (writeResult, bool) {
+	if result, ok := d.results[key]; ok {
+		return result, true
+	}
+	if events, ok := d.applied[key.CustomerID]; ok && (events.contains(key.EventID) || events.unknown(key.EventID)) {
+		return writeResult{Forgotten: true}, true
+	}
+	return writeResult{}, false
+}
+
+// seen reports whether the window knows the write event was applied.
+func (d *dedupWindow) seen(key writeKey) bool {
+	if _, ok := d.results[key]; ok {
+		return true
+	}
+	events, ok := d.applied[key.CustomerID]
+	return ok && events.contains(key.EventID)
+}
+
+// record remembers the result of a newly applied write event.
+func (d *dedupWindow) record(key writeKey, result writeResult) {
+	if _, ok := d.results[key]; ok {
+		return
+	}
+	d.results[key] = result
+	d.order = append(d.order, key)
+	for len(d.order) > d.size {
+		delete(d.results, d.order[0])
+		d.order = d.order[1:]
+	}
+	events, ok := d.applied[key.CustomerID]
+	if !ok {
+		events = &appliedEvents{}
+		d.applied[key.CustomerID] = events
+	}
+	events.add(key.EventID)
+}
+
+// appliedEvents is the set of one customer's event IDs a branch has applied,
+// in bounded memory: every ID up to the watermark, and the IDs in the tail
+// above it. Customers number their events in the order they make them, so
+// the tail fills up with the IDs of the writes made since the customer's
+// last gap, which are mostly the IDs of queries. Once the tail is full, its
+// lowest IDs are dropped, and whether an ID up to the highest one dropped
+// was applied is unknown. A gap is never taken for applied, since a write
+// still on its way from another branch may fill it.
+type appliedEvents struct {
+	watermark int32
+	tail      []int32 // Sorted
+	forgotten int32   // Highest ID dropped from the tail
+}
+
+func (a *appliedEvents) contains(eventID int32) bool {
+	if eventID <= a.watermark {
+		return true
+	}
+	i := sort.Search(len(a.tail), func(i int) bool { return a.tail[i] >= eventID })
+	return i < len(a.tail) && a.tail[i] == eventID
+}
+
+// unknown reports whether the ID is too old to tell if it was applied.
+func (a *appliedEvents) unknown(eventID int32) bool {
+	return eventID <= a.forgotten && !a.contains(eventID)
+}
+
+func (a *appliedEvents) add(eventID int32) {
+	if a.contains(eventID) {
+		return
+	}
+	i := sort.Search(len(a.tail), func(i int) bool { return a.tail[i] >= eventID })
+	a.tail = append(a.tail, 0)
+	copy(a.tail[i+1:], a.tail[i:])
+	a.tail[i] = eventID
+
+	// Fold the IDs that continue the watermark into it, and drop the lowest
+	// IDs of a tail that is still too long
+	n := 0
+	for n < len(a.tail) && a.tail[n] == a.watermark+1 {
+		a.watermark++
+		n++
+	}
+	if extra := len(a.tail) - n - appliedTail; extra > 0 {
+		n += extra
+		if a.tail[n-1] > a.forgotten {
+			a.forgotten = a.tail[n-1]
+		}
+	}
+	if n > 0 {
+		a.tail = append(a.tail[:0], a.tail[n:]...)
+	}
+}
+
+// SetDedupWindow changes how many recent write events the branch remembers
+// the results of. It must be called before the branch starts serving.
+func (s *BranchServer) SetDedupWindow(size int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.dedup.size = size
+}
+
+// replayed looks up a write event the branch has already applied. Writes
+// without an event ID are never deduplicated. The result of a write applied
+// long ago may be forgotten. The caller must hold s.mu.
+func (s *BranchServer) replayed(customerID int32, eventID int32) (writeResult, bool) {
+	if eventID == 0 {
+		return writeResult{}, false
+	}
+	return s.dedup.lookup(writeKey{CustomerID: customerID, EventID: eventID})
+}
+
+// madeBefore reports whether the branch knows it applied the write event
+// under its customer and event ID already, as it has when the customer
+// retried the write at another branch. A write the branch cannot tell about
+// counts as new, since it may be one still on its way from another branch.
+// The caller must hold s.mu.
+func (s *BranchServer) madeBefore(customerID int32, eventID int32) bool {
+	if eventID == 0 {
+		return false
+	}
+	return s.dedup.seen(writeKey{CustomerID: customerID, EventID: eventID})
+}
+
+// remember records the result of a write event the branch has just applied.
+// The caller must hold s.mu.
+func (s *BranchServer) remember(customerID int32, eventID int32, result writeResult) {
+	if eventID == 0 {
+		return
+	}
+	s.dedup.record(writeKey{CustomerID: customerID, EventID: eventID}, result)
+}
+
+// errForgotten answers a retried write event whose result the branch no
+// longer remembers, or that is too old for it to tell whether it was applied.
+func errForgotten(customerID int32, eventID int32) error {
+	return status.Errorf(codes.AlreadyExists, "write event %d of customer %d is too old for the branch to answer its retry", eventID, customerID)
+}
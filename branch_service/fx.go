@@ -0,0 +1,109 @@
+package branch_service
+
+import (
+	"branch_service/branch"
+	"bufio"
+	"context"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RateProvider quotes the exchange rates ConvertCurrency uses.
+type RateProvider interface {
+	// Rate returns what one major unit of from is worth in major units of
+	// to, such as 0.92 for USD to EUR.
+	Rate(ctx context.Context, from string, to string) (*big.Rat, error)
+}
+
+// RatesTable is a RateProvider with a fixed table of rates. A pair that is
+// only listed one way round is quoted the other way at the inverse rate.
+type RatesTable struct {
+	rates map[[2]string]*big.Rat
+}
+
+// NewRatesTable creates an empty rates table.
+func NewRatesTable() *RatesTable {
+	return &RatesTable{rates: make(map[[2]string]*big.Rat)}
+}
+
+// Set sets the rate from one currency to another.
+func (t *RatesTable) Set(from string, to string, rate *big.Rat) {
+	t.rates[[2]string{strings.ToUpper(from), strings.ToUpper(to)}] = rate
+}
+
+// Rate implements RateProvider.
+func (t *RatesTable) Rate(ctx context.Context, from string, to string) (*big.Rat, error) {
+	if rate, ok := t.rates[[2]string{from, to}]; ok {
+		return rate, nil
+	}
+	if rate, ok := t.rates[[2]string{to, from}]; ok {
+		return new(big.Rat).Inv(rate), nil
+	}
+	return nil, status.Errorf(codes.NotFound, "no exchange rate from %s to %s", from, to)
+}
+
+// LoadRatesFile reads a rates table from a text file with one rate per line,
+// such as "USD EUR 0.92". Blank lines and lines starting with # are skipped.
+func LoadRatesFile(filename string) (*RatesTable, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, fmt.Errorf("error reading rates file: %v", err)
+	}
+	defer file.Close()
+
+	table := NewRatesTable()
+	scanner := bufio.NewScanner(file)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		fields := strings.Fields(text)
+		if len(fields) != 3 {
+			return nil, fmt.Errorf("%s:%d: expected \"FROM TO RATE\"", filename, line)
+		}
+		rate, ok := new(big.Rat).SetString(fields[2])
+		if !ok || rate.Sign() <= 0 {
+			return nil, fmt.Errorf("%s:%d: invalid rate %q", filename, line, fields[2])
+		}
+		table.Set(fields[0], fields[1], rate)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading rates file: %v", err)
+	}
+	return table, nil
+}
+
+// quote converts an amount into another currency at the rate the branch's
+// rate provider quotes, rounding down to a whole minor unit.
+func (s *BranchServer) quote(ctx context.Context, money *branch.Money, to string) (*branch.Money, error) {
+	if s.Rates == nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "branch %d has no exchange rates", s.ID)
+	}
+	rate, err := s.Rates.Rate(ctx, money.CurrencyCode, to)
+	if err != nil {
+		return nil, err
+	}
+
+	// Scale the minor units of one currency to the minor units of the other
+	numerator := new(big.Int).Mul(big.NewInt(money.Units), rate.Num())
+	numerator.Mul(numerator, pow10(MinorDigits(to)))
+	denominator := new(big.Int).Mul(rate.Denom(), pow10(MinorDigits(money.CurrencyCode)))
+	units := numerator.Quo(numerator, denominator)
+	if !units.IsInt64() {
+		return nil, status.Errorf(codes.OutOfRange, "converted amount would overflow")
+	}
+	if units.Sign() == 0 && money.Units > 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "%s %s is too little to convert to %s", FormatMoney(money), money.CurrencyCode, to)
+	}
+	return &branch.Money{CurrencyCode: to, Units: units.Int64()}, nil
+}
+
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
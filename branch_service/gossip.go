@@ -0,0 +1,265 @@
+package branch_service
+
+import (
+	"branch_service/branch"
+	"context"
+	"log"
+	"math/rand"
+	"sort"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// DefaultGossipInterval is how often a branch probes one of its peers,
+	// and DefaultSuspectTimeout how long a peer stays suspected before it is
+	// declared dead, unless the branch is configured otherwise.
+	DefaultGossipInterval = time.Second
+	DefaultSuspectTimeout = 5 * time.Second
+
+	// indirectProbes is how many other peers a branch asks to probe a peer
+	// it could not reach itself.
+	indirectProbes = 3
+)
+
+// memberHealth is what a branch believes about one of its peers. A belief
+// with a higher incarnation replaces one with a lower, and at the same
+// incarnation dead overrides suspect, which overrides alive.
+type memberHealth struct {
+	state       branch.Member_State
+	incarnation int64
+	changed     time.Time // When the state last changed
+}
+
+// ListMembers lists this branch and its peers, with the state this branch
+// believes each peer is in.
+func (s *BranchServer) ListMembers(ctx context.Context, request *branch.ListMembersRequest) (*branch.ListMembersResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return &branch.ListMembersResponse{
+		Members: s.gossipMembers(),
+	}, nil
+}
+
+// Ping answers a probe from a peer, and merges the peer's view of the
+// cluster into this branch's.
+func (s *BranchServer) Ping(ctx context.Context, request *branch.PingRequest) (*branch.PingResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.mergeMembers(request.Members)
+	return &branch.PingResponse{
+		Members: s.gossipMembers(),
+	}, nil
+}
+
+// PingReq probes the target for a peer that could not reach it directly.
+func (s *BranchServer) PingReq(ctx context.Context, request *branch.PingReqRequest) (*branch.PingResponse, error) {
+	s.mu.Lock()
+	s.mergeMembers(request.Members)
+	client, ok := s.peers[request.Target]
+	members := s.gossipMembers()
+	s.mu.Unlock()
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "branch %d is not a peer of branch %d", request.Target, s.ID)
+	}
+
+	probeCtx, cancel := context.WithTimeout(ctx, s.probeTimeout())
+	defer cancel()
+	response, err := client.Ping(probeCtx, &branch.PingRequest{From: s.ID, Members: members}, grpc.WaitForReady(true))
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "branch %d did not answer: %v", request.Target, err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.mergeMembers(response.Members)
+	return &branch.PingResponse{
+		Members: s.gossipMembers(),
+	}, nil
+}
+
+// runGossip probes one peer per interval, in a random order that visits
+// every peer once per round, and declares dead the peers that stayed
+// suspected for too long, until the branch stops.
+func (s *BranchServer) runGossip() {
+	ticker := time.NewTicker(s.GossipInterval)
+	defer ticker.Stop()
+	var round []int32
+	for {
+		select {
+		case <-ticker.C:
+		case <-s.stop:
+			return
+		}
+		s.mu.Lock()
+		if len(round) == 0 {
+			for peerID := range s.peers {
+				round = append(round, peerID)
+			}
+			rand.Shuffle(len(round), func(i, j int) { round[i], round[j] = round[j], round[i] })
+		}
+		s.expireSuspects()
+		s.mu.Unlock()
+
+		if len(round) > 0 {
+			s.probe(round[0])
+			round = round[1:]
+		}
+	}
+}
+
+// probe pings a peer, and if it does not answer, asks other peers to ping
+// it. A peer nobody could reach is suspected.
+func (s *BranchServer) probe(target int32) {
+	s.mu.Lock()
+	client, ok := s.peers[target]
+	members := s.gossipMembers()
+	var helpers []branch.BranchServiceClient
+	for peerID, helper := range s.peers {
+		if peerID != target && s.peerUp(peerID) {
+			helpers = append(helpers, helper)
+		}
+	}
+	s.mu.Unlock()
+	if !ok {
+		// The peer left the cluster during the round
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), s.probeTimeout())
+	// Wait out a connection still being set up, as at startup, rather than
+	// failing at once
+	response, err := client.Ping(ctx, &branch.PingRequest{From: s.ID, Members: members}, grpc.WaitForReady(true))
+	cancel()
+	if err != nil {
+		response, err = s.probeIndirectly(target, members, helpers)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err != nil {
+		if health, ok := s.health[target]; ok && health.state == branch.Member_ALIVE {
+			s.setHealth(target, branch.Member_SUSPECT, health.incarnation)
+		}
+		return
+	}
+	s.mergeMembers(response.Members)
+}
+
+// probeIndirectly asks up to indirectProbes of the helpers to ping the
+// target, and returns the first answer that reaches this branch.
+func (s *BranchServer) probeIndirectly(target int32, members []*branch.Member, helpers []branch.BranchServiceClient) (*branch.PingResponse, error) {
+	rand.Shuffle(len(helpers), func(i, j int) { helpers[i], helpers[j] = helpers[j], helpers[i] })
+	if len(helpers) > indirectProbes {
+		helpers = helpers[:indirectProbes]
+	}
+	if len(helpers) == 0 {
+		return nil, status.Errorf(codes.Unavailable, "branch %d did not answer", target)
+	}
+
+	// Give the helpers time for their own probe of the target
+	ctx, cancel := context.WithTimeout(context.Background(), 2*s.probeTimeout())
+	defer cancel()
+	type answer struct {
+		response *branch.PingResponse
+		err      error
+	}
+	answers := make(chan answer, len(helpers))
+	for _, helper := range helpers {
+		go func(helper branch.BranchServiceClient) {
+			response, err := helper.PingReq(ctx, &branch.PingReqRequest{From: s.ID, Target: target, Members: members})
+			answers <- answer{response, err}
+		}(helper)
+	}
+	var err error
+	for range helpers {
+		a := <-answers
+		if a.err == nil {
+			return a.response, nil
+		}
+		err = a.err
+	}
+	return nil, err
+}
+
+// probeTimeout is how long a branch waits for a peer to answer a ping.
+func (s *BranchServer) probeTimeout() time.Duration {
+	return s.GossipInterval / 2
+}
+
+// mergeMembers updates this branch's view of the cluster with a peer's. A
+// branch that hears it is suspected or dead refutes it by raising its
+// incarnation, which the next gossip spreads. Branches that are not peers
+// of this one are ignored, since they join through JoinCluster. The caller
+// must hold s.mu.
+func (s *BranchServer) mergeMembers(members []*branch.Member) {
+	for _, member := range members {
+		if member.Id == s.ID {
+			if member.State != branch.Member_ALIVE && member.Incarnation >= s.incarnation {
+				s.incarnation = member.Incarnation + 1
+				log.Printf("Branch %d refuted that it is %s with incarnation %d", s.ID, member.State, s.incarnation)
+			}
+			continue
+		}
+		health, ok := s.health[member.Id]
+		if !ok {
+			continue
+		}
+		if member.Incarnation > health.incarnation || member.Incarnation == health.incarnation && member.State > health.state {
+			s.setHealth(member.Id, member.State, member.Incarnation)
+		}
+	}
+}
+
+// expireSuspects declares dead the peers that stayed suspected longer than
+// SuspectTimeout. The caller must hold s.mu.
+func (s *BranchServer) expireSuspects() {
+	for peerID, health := range s.health {
+		if health.state == branch.Member_SUSPECT && time.Since(health.changed) >= s.SuspectTimeout {
+			s.setHealth(peerID, branch.Member_DEAD, health.incarnation)
+		}
+	}
+}
+
+// setHealth records what this branch believes about a peer. Writes for a
+// dead peer stay queued in its outbox until it is alive again, instead of
+// being retried. The caller must hold s.mu.
+func (s *BranchServer) setHealth(peerID int32, state branch.Member_State, incarnation int64) {
+	health := s.health[peerID]
+	health.incarnation = incarnation
+	if health.state == state {
+		return
+	}
+	log.Printf("Branch %d believes branch %d is %s (incarnation %d)", s.ID, peerID, state, incarnation)
+	health.state = state
+	health.changed = time.Now()
+	if o, ok := s.outboxes[peerID]; ok {
+		o.setDown(state == branch.Member_DEAD)
+	}
+}
+
+// peerUp reports whether a peer is not believed dead. The caller must hold
+// s.mu.
+func (s *BranchServer) peerUp(peerID int32) bool {
+	health, ok := s.health[peerID]
+	return !ok || health.state != branch.Member_DEAD
+}
+
+// gossipMembers lists this branch, then every peer with what this branch
+// believes about it. The caller must hold s.mu.
+func (s *BranchServer) gossipMembers() []*branch.Member {
+	members := []*branch.Member{{Id: s.ID, Address: s.Address, Incarnation: s.incarnation}}
+	for peerID := range s.peers {
+		member := &branch.Member{Id: peerID, Address: s.members[peerID]}
+		if health, ok := s.health[peerID]; ok {
+			member.State = health.state
+			member.Incarnation = health.incarnation
+		}
+		members = append(members, member)
+	}
+	sort.Slice(members[1:], func(i, j int) bool { return members[i+1].Id < members[j+1].Id })
+	return members
+}
@@ -0,0 +1,177 @@
+package branch_service
+
+import (
+	"branch_service/branch"
+	"context"
+	"io"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// applyWrite moves the money of a write event that is next in causal order
+// and records it in the branch's history. Whether a write is next is decided
+// by its origin and vector clock alone. A write the customer is known to have
+// already made under the same event ID, at this branch or another, only
+// advances the vector clock, since its money has already moved, and is
+// recorded as a duplicate. A write that would overflow the balance is not
+// applied at all. A branch with a data directory logs the write first, and
+// the store records the write with the accounts it changed. The caller must
+// hold s.mu.
+func (s *BranchServer) applyWrite(write *branch.WriteEvent) error {
+	if err := s.logApplied(write); err != nil {
+		return err
+	}
+	replayed := s.madeBefore(write.CustomerId, write.WriteEventID)
+	write.Duplicate = replayed
+	var changed []*Account
+	if !replayed {
+		var err error
+		if changed, err = s.move(write); err != nil {
+			return err
+		}
+	}
+	if err := s.store.RecordApplied(write, changed...); err != nil {
+		return s.storeFailed(err)
+	}
+	if !replayed {
+		s.remember(write.CustomerId, write.WriteEventID, resultOf(write))
+	}
+	s.vector[write.OriginBranch] = write.Vector[write.OriginBranch]
+	s.notifyApplied()
+	return nil
+}
+
+// applyRemote applies a write event that originated at a peer once everything
+// it depends on has been applied, skipping it if it was applied already. The
+// caller must hold s.mu.
+func (s *BranchServer) applyRemote(ctx context.Context, write *branch.WriteEvent) error {
+	apply, err := s.awaitCausal(ctx, write.OriginBranch, write.Vector)
+	if err != nil {
+		return err
+	}
+	if apply {
+		return s.applyWrite(write)
+	}
+	return nil
+}
+
+// missingWrites lists, in the order this branch applied them, the first
+// write events not covered by vector, at most limit of them, and reports
+// whether there are more. The caller must hold s.mu.
+func (s *BranchServer) missingWrites(vector map[int32]int64, limit int) ([]*branch.WriteEvent, bool, error) {
+	var writes []*branch.WriteEvent
+	more := false
+	err := s.store.History(func(write *branch.WriteEvent) error {
+		if write.Vector[write.OriginBranch] <= vector[write.OriginBranch] {
+			return nil
+		}
+		if len(writes) == limit {
+			more = true
+			return io.EOF
+		}
+		writes = append(writes, write)
+		return nil
+	})
+	if err != nil && err != io.EOF {
+		return nil, false, s.storeFailed(err)
+	}
+	return writes, more, nil
+}
+
+// compactHistory drops the oldest write events from the history once this
+// branch and every peer have them, keeping the last writes the dedup window
+// could hold so that a store rebuilds its results on restart. A peer that has
+// not synced with this branch yet holds compaction back. In Raft mode peers
+// catch up through the Raft log instead, so only the dedup window does. The
+// caller must hold s.mu.
+func (s *BranchServer) compactHistory() error {
+	covered := s.vector.Copy()
+	if s.raft == nil {
+		for peerID := range s.peers {
+			acked, ok := s.acked[peerID]
+			if !ok {
+				return nil
+			}
+			for origin, count := range covered {
+				if acked[origin] < count {
+					covered[origin] = acked[origin]
+				}
+			}
+		}
+	}
+
+	var dropped []*branch.WriteEvent
+	retained := 0
+	err := s.store.History(func(write *branch.WriteEvent) error {
+		if retained == 0 && write.Vector[write.OriginBranch] <= covered[write.OriginBranch] {
+			dropped = append(dropped, write)
+		} else {
+			retained++
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if keep := s.dedup.size - retained; keep > 0 {
+		dropped = dropped[:max(len(dropped)-keep, 0)]
+	}
+	if len(dropped) == 0 {
+		return nil
+	}
+
+	base, err := s.store.HistoryBase()
+	if err != nil {
+		return err
+	}
+	base = copyBase(base)
+	for _, write := range dropped {
+		addToBase(base, write)
+	}
+	return s.store.CompactHistory(len(dropped), base)
+}
+
+// copyBase copies a history base, which is empty if base is nil, so that more
+// write events can be summed up in it.
+func copyBase(base *branch.HistoryBase) *branch.HistoryBase {
+	if base == nil {
+		base = &branch.HistoryBase{}
+	} else {
+		base = proto.Clone(base).(*branch.HistoryBase)
+	}
+	if base.Vector == nil {
+		base.Vector = make(map[int32]int64)
+	}
+	if base.Forgotten == nil {
+		base.Forgotten = make(map[int32]int32)
+	}
+	return base
+}
+
+// addToBase sums up one more write event in a history base.
+func addToBase(base *branch.HistoryBase, write *branch.WriteEvent) {
+	base.Compacted++
+	if count := write.Vector[write.OriginBranch]; count > base.Vector[write.OriginBranch] {
+		base.Vector[write.OriginBranch] = count
+	}
+	if write.Timestamp > base.Timestamp {
+		base.Timestamp = write.Timestamp
+	}
+	if write.WriteEventID > base.Forgotten[write.CustomerId] {
+		base.Forgotten[write.CustomerId] = write.WriteEventID
+	}
+}
+
+// historyBase returns what the compacted part of the history sums up to,
+// which is empty if the history was never compacted. The caller must hold
+// s.mu.
+func (s *BranchServer) historyBase() (*branch.HistoryBase, error) {
+	base, err := s.store.HistoryBase()
+	if err != nil {
+		return nil, s.storeFailed(err)
+	}
+	if base == nil {
+		base = &branch.HistoryBase{}
+	}
+	return base, nil
+}
@@ -0,0 +1,166 @@
+package branch_service
+
+import (
+	"branch_service/branch"
+	"context"
+	"runtime"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// heapInUse returns the bytes of heap in use after a garbage collection.
+func heapInUse() uint64 {
+	runtime.GC()
+	var stats runtime.MemStats
+	runtime.ReadMemStats(&stats)
+	return stats.HeapAlloc
+}
+
+// TestHistorySoak makes a million deposits and checks that compacting the
+// history keeps the branch's memory flat, while retries and balances stay
+// right.
+func TestHistorySoak(t *testing.T) {
+	if testing.Short() {
+		t.Skip("soak test")
+	}
+	const (
+		customers = 100
+		rounds    = 5
+		perRound  = 200000
+	)
+	s := NewBranchServer(1, &branch.Money{CurrencyCode: "USD", Units: 0}, 0)
+	ctx := context.Background()
+	next := make(map[int32]int32)
+	var first uint64
+	for round := 0; round < rounds; round++ {
+		for i := 0; i < perRound; i++ {
+			customerID := int32(i % customers)
+			// Leave gaps, as the event IDs of queries do
+			next[customerID] += 1 + int32(i%3)
+			_, err := s.Deposit(ctx, &branch.DepositRequest{
+				CustomerId:   customerID,
+				WriteEventID: next[customerID],
+				Money:        &branch.Money{CurrencyCode: "USD", Units: 1},
+			})
+			if err != nil {
+				t.Fatalf("deposit %d of round %d: %v", i, round, err)
+			}
+		}
+
+		s.mu.Lock()
+		err := s.compactHistory()
+		s.mu.Unlock()
+		if err != nil {
+			t.Fatalf("compacting the history: %v", err)
+		}
+		heap := heapInUse()
+		t.Logf("round %d: %d KiB of heap", round, heap/1024)
+		if round == 0 {
+			first = heap
+		} else if heap > first+first/2 {
+			t.Fatalf("heap grew from %d KiB to %d KiB", first/1024, heap/1024)
+		}
+	}
+
+	// A retry of a recent write returns its result without moving money again
+	last := next[0]
+	response, err := s.Deposit(ctx, &branch.DepositRequest{
+		CustomerId:   0,
+		WriteEventID: last,
+		Money:        &branch.Money{CurrencyCode: "USD", Units: 1},
+	})
+	if err != nil {
+		t.Fatalf("retrying the last deposit: %v", err)
+	}
+	want := int64(rounds * perRound / customers)
+	if response.Balance.GetUnits() != want {
+		t.Fatalf("retry returned balance %d, want %d", response.Balance.GetUnits(), want)
+	}
+
+	// The statement picks up where the compacted history left off
+	s.mu.Lock()
+	history, err := s.accountHistory(0)
+	s.mu.Unlock()
+	if err != nil {
+		t.Fatalf("copying the account's history: %v", err)
+	}
+	entries, err := history.statement(&branch.StatementRequest{CustomerId: 0, PageSize: MaxStatementPageSize})
+	if err != nil {
+		t.Fatalf("reading the statement: %v", err)
+	}
+	if len(entries) == 0 {
+		t.Fatal("the statement is empty")
+	}
+	// Customer 0 made the first write of every hundred
+	final := entries[len(entries)-1]
+	position := int64(rounds*perRound - customers + 1)
+	if final.Balance.GetUnits() != want || final.Position != position {
+		t.Fatalf("last statement entry has balance %d at position %d, want %d at %d", final.Balance.GetUnits(), final.Position, want, position)
+	}
+}
+
+// TestCompactionWaitsForPeers checks that a write stays in the history until
+// every peer has synced it, and that a peer that left no longer holds
+// compaction back.
+func TestCompactionWaitsForPeers(t *testing.T) {
+	servers, clients := startTestBranches(t, 2, 0, func(s *BranchServer) {
+		s.SetDedupWindow(1)
+		// The test compacts the history itself
+		s.AntiEntropyInterval = time.Hour
+	})
+	s, client := servers[0], clients[0]
+	ctx := context.Background()
+	// Branch 2 receives the writes, but only syncs when the test says so
+	s.RegisterPeer(servers[1].ID, servers[1].Address, clients[1])
+
+	for i := int32(1); i <= 10; i++ {
+		_, err := client.Deposit(ctx, &branch.DepositRequest{CustomerId: 1, WriteEventID: i, Money: &branch.Money{CurrencyCode: "USD", Units: 1}})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	compact := func() int {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if err := s.compactHistory(); err != nil {
+			t.Fatal(err)
+		}
+		writes, _, err := s.missingWrites(nil, MaxSyncWrites)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return len(writes)
+	}
+	if kept := compact(); kept != 10 {
+		t.Fatalf("kept %d writes before the peer synced, want 10", kept)
+	}
+
+	// The peer has the first 6 writes
+	_, err := client.SyncWrites(ctx, &branch.SyncWritesRequest{BranchId: 2, Vector: map[int32]int64{1: 6}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if kept := compact(); kept != 4 {
+		t.Fatalf("kept %d writes after the peer synced 6, want 4", kept)
+	}
+
+	// A branch missing compacted writes cannot catch up through anti-entropy
+	_, err = client.SyncWrites(ctx, &branch.SyncWritesRequest{BranchId: 3, Vector: map[int32]int64{1: 2}})
+	if status.Code(err) != codes.OutOfRange {
+		t.Fatalf("syncing writes that were compacted: got %v, want %v", err, codes.OutOfRange)
+	}
+
+	// Once the peer has left, only the dedup window is kept
+	if _, err := client.LeaveCluster(ctx, &branch.LeaveClusterRequest{BranchId: 2}); err != nil {
+		t.Fatal(err)
+	}
+	if kept := compact(); kept != 1 {
+		t.Fatalf("kept %d writes after the peer left, want 1", kept)
+	}
+
+	awaitBalances(t, clients, map[int32]int64{1: 10}, 0)
+}
@@ -0,0 +1,299 @@
+package branch_service
+
+import (
+	"branch_service/branch"
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"sort"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// drainPollInterval is how often a leaving or stopping branch checks whether
+// its peers have received every write it owes them.
+const drainPollInterval = 50 * time.Millisecond
+
+// JoinCluster adds a branch that is joining the cluster as a peer of this
+// branch, and sends it a snapshot of this branch's state to start from if it
+// asks for one. The snapshot leaves out the history, whose size is not
+// bounded, and sums it up instead, so the new branch's history starts at the
+// snapshot. Writes applied here after the snapshot are propagated to the new
+// branch like to any other peer.
+func (s *BranchServer) JoinCluster(ctx context.Context, request *branch.JoinClusterRequest) (*branch.JoinClusterResponse, error) {
+
+	if s.raft != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "a Raft cluster cannot change its members")
+	}
+	if err := s.checkMembersCanChange(); err != nil {
+		return nil, err
+	}
+	member := request.Member
+	if member.GetAddress() == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "a joining branch must give the address it serves on")
+	}
+	if member.Id == s.ID {
+		return nil, status.Errorf(codes.InvalidArgument, "branch %d is already a member", s.ID)
+	}
+	conn, err := s.dialBranch(member.Address, member.Id)
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "%v", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.left {
+		conn.Close()
+		return nil, status.Errorf(codes.FailedPrecondition, "branch %d has left the cluster", s.ID)
+	}
+
+	// Register the peer and take the snapshot at once, so every write
+	// applied here is either in the snapshot or queued for the peer
+	s.addPeer(member.Id, member.Address, branch.NewBranchServiceClient(conn), conn)
+	response := &branch.JoinClusterResponse{Members: s.memberList()}
+	if request.Snapshot {
+		response.Snapshot, err = s.snapshotState(0, false)
+		if err != nil {
+			return nil, err
+		}
+		response.OpeningBalance = s.money(s.openingBalance)
+	}
+	log.Printf("Branch %d added branch %d at %s to the cluster", s.ID, member.Id, member.Address)
+	return response, nil
+}
+
+// LeaveCluster takes this branch out of the cluster if the request names it
+// or no branch, or otherwise stops propagating to the branch it names.
+func (s *BranchServer) LeaveCluster(ctx context.Context, request *branch.LeaveClusterRequest) (*branch.LeaveClusterResponse, error) {
+
+	if s.raft != nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "a Raft cluster cannot change its members")
+	}
+	if err := s.checkMembersCanChange(); err != nil {
+		return nil, err
+	}
+	if request.BranchId == s.ID || request.BranchId == 0 {
+		if err := s.Leave(ctx); err != nil {
+			return nil, err
+		}
+	} else {
+		s.mu.Lock()
+		s.removePeer(request.BranchId)
+		s.mu.Unlock()
+		log.Printf("Branch %d removed branch %d from the cluster", s.ID, request.BranchId)
+	}
+	return &branch.LeaveClusterResponse{
+		Success: true,
+	}, nil
+}
+
+// Join adds the branch to the cluster of the member at address. The member
+// sends a snapshot of its state for the branch to start from, and then every
+// member adds the branch as a peer; the writes the members apply meanwhile
+// reach it through anti-entropy. A branch that restored its state from disk
+// rejoins without a snapshot, and catches up through anti-entropy too. Join
+// must be called on a branch without peers, after OpenDataDir or SetStore and
+// before the branch starts. Since the members need the address the branch
+// serves on, a branch on port 0 cannot join.
+func (s *BranchServer) Join(ctx context.Context, address string) error {
+	if s.raft != nil {
+		return fmt.Errorf("a Raft cluster cannot change its members")
+	}
+	if err := s.checkMembersCanChange(); err != nil {
+		return err
+	}
+	if _, port, err := net.SplitHostPort(s.Address); err == nil && port == "0" {
+		return fmt.Errorf("branch %d cannot join on port 0, since it does not know its port until it starts", s.ID)
+	}
+	s.mu.Lock()
+	fresh := len(s.vector) == 0
+	if len(s.peers) != 0 {
+		s.mu.Unlock()
+		return fmt.Errorf("branch %d already has peers", s.ID)
+	}
+	s.mu.Unlock()
+
+	seedConn, err := s.dialBranch(address, 0)
+	if err != nil {
+		return err
+	}
+	seed := branch.NewBranchServiceClient(seedConn)
+	self := &branch.Member{Id: s.ID, Address: s.Address}
+	response, err := seed.JoinCluster(ctx, &branch.JoinClusterRequest{Member: self, Snapshot: fresh})
+	if err != nil {
+		seedConn.Close()
+		return fmt.Errorf("error joining the cluster through %s: %v", address, err)
+	}
+	if fresh {
+		if currency := response.OpeningBalance.GetCurrencyCode(); currency != s.currency {
+			return fmt.Errorf("the cluster keeps accounts in %s, not %s", currency, s.currency)
+		}
+		s.mu.Lock()
+		s.openingBalance = response.OpeningBalance.Units
+		err := s.restore(response.Snapshot)
+		s.mu.Unlock()
+		if err != nil {
+			return err
+		}
+	}
+
+	// The member that sent the snapshot lists itself first
+	for i, member := range response.Members {
+		if member.Id == s.ID {
+			continue
+		}
+		client, conn := seed, seedConn
+		if i > 0 {
+			if conn, err = s.dialBranch(member.Address, member.Id); err != nil {
+				return err
+			}
+			client = branch.NewBranchServiceClient(conn)
+			if _, err := client.JoinCluster(ctx, &branch.JoinClusterRequest{Member: self}); err != nil {
+				conn.Close()
+				return fmt.Errorf("error joining branch %d at %s: %v", member.Id, member.Address, err)
+			}
+		}
+		s.mu.Lock()
+		s.addPeer(member.Id, member.Address, client, conn)
+		s.mu.Unlock()
+	}
+
+	// Log the restored state, which is not in the write-ahead log
+	if fresh && s.wal != nil {
+		if err := s.takeSnapshot(true); err != nil {
+			return err
+		}
+	}
+	log.Printf("Branch %d joined the cluster through %s with %d peers", s.ID, address, len(response.Members))
+	return nil
+}
+
+// Leave takes the branch out of its cluster. The branch refuses new writes,
+// waits until the peers not believed dead have every write it owes them, and
+// then has every peer stop propagating to it. If ctx ends first, the branch keeps refusing
+// writes and Leave can be called again.
+func (s *BranchServer) Leave(ctx context.Context) error {
+	if err := s.checkMembersCanChange(); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	s.left = true
+	outboxes := s.outboxList()
+	s.mu.Unlock()
+	if err := awaitDrained(ctx, outboxes); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	peers := s.peerClients()
+	s.mu.Unlock()
+	var firstErr error
+	for peerID, client := range peers {
+		if _, err := client.LeaveCluster(ctx, &branch.LeaveClusterRequest{BranchId: s.ID}); err != nil {
+			log.Printf("Branch %d failed to tell branch %d it is leaving: %v", s.ID, peerID, err)
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+
+	s.mu.Lock()
+	for peerID := range s.peers {
+		s.removePeer(peerID)
+	}
+	s.mu.Unlock()
+	log.Printf("Branch %d left the cluster", s.ID)
+	return firstErr
+}
+
+// outboxList returns the outboxes of every peer. The caller must hold s.mu.
+func (s *BranchServer) outboxList() []*outbox {
+	outboxes := make([]*outbox, 0, len(s.outboxes))
+	for _, o := range s.outboxes {
+		outboxes = append(outboxes, o)
+	}
+	return outboxes
+}
+
+// awaitDrained waits until the outbox of every peer not believed dead has
+// delivered its queued write events, or ctx ends. A dead peer would never
+// take them; it pulls what it is missing from the other peers through
+// anti-entropy once it is back.
+func awaitDrained(ctx context.Context, outboxes []*outbox) error {
+	ticker := time.NewTicker(drainPollInterval)
+	defer ticker.Stop()
+	for _, o := range outboxes {
+		for !o.drained() && !o.isDown() {
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return status.FromContextError(ctx.Err()).Err()
+			}
+		}
+	}
+	return nil
+}
+
+// removePeer stops propagating to a peer that left the cluster. The caller
+// must hold s.mu.
+func (s *BranchServer) removePeer(peerID int32) {
+	if o, ok := s.outboxes[peerID]; ok {
+		o.close()
+	}
+	if conn, ok := s.conns[peerID]; ok {
+		conn.Close()
+	}
+	delete(s.outboxes, peerID)
+	delete(s.conns, peerID)
+	delete(s.peers, peerID)
+	delete(s.members, peerID)
+	delete(s.health, peerID)
+	delete(s.acked, peerID)
+}
+
+// memberList lists this branch, then every peer whose address it knows. The
+// caller must hold s.mu.
+func (s *BranchServer) memberList() []*branch.Member {
+	members := []*branch.Member{{Id: s.ID, Address: s.Address}}
+	for peerID := range s.peers {
+		if address, ok := s.members[peerID]; ok {
+			members = append(members, &branch.Member{Id: peerID, Address: address})
+		}
+	}
+	sort.Slice(members[1:], func(i, j int) bool { return members[i+1].Id < members[j+1].Id })
+	return members
+}
+
+// checkMembersCanChange refuses to add or remove members while withdrawals go
+// to primary branches. The primary of an account is picked among the members,
+// so while some branches know of a change and others do not, two branches
+// could both accept withdrawals from the same account as its primary.
+func (s *BranchServer) checkMembersCanChange() error {
+	if s.WithdrawalMode == PrimaryWithdrawals {
+		return status.Errorf(codes.FailedPrecondition, "the members of a cluster cannot change while withdrawals go to primary branches")
+	}
+	return nil
+}
+
+// checkMember refuses writes at a branch that has left the cluster, since
+// they would never reach the other branches. The caller must hold s.mu.
+func (s *BranchServer) checkMember() error {
+	if s.left {
+		return status.Errorf(codes.FailedPrecondition, "branch %d has left the cluster", s.ID)
+	}
+	return nil
+}
+
+// dialBranch connects to the branch with the given ID at address, or to
+// whichever branch is there if the ID is 0.
+func (s *BranchServer) dialBranch(address string, id int32) (*grpc.ClientConn, error) {
+	conn, err := grpc.Dial(address, BranchDialOption(s.TLS, id))
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to branch at %s: %v", address, err)
+	}
+	return conn, nil
+}
@@ -0,0 +1,136 @@
+package branch_service
+
+import (
+	"branch_service/branch"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// DefaultCurrency is the currency of amounts that don't name one.
+const DefaultCurrency = "USD"
+
+// minorDigits lists currencies whose minor unit is not a hundredth of the
+// major unit. Every other currency has two digits of minor units.
+var minorDigits = map[string]int{
+	"BHD": 3,
+	"JPY": 0,
+	"KRW": 0,
+	"KWD": 3,
+	"OMR": 3,
+	"TND": 3,
+}
+
+// MinorDigits returns how many decimal digits the minor unit of a currency
+// has, for example 2 for cents of USD.
+func MinorDigits(currency string) int {
+	if digits, ok := minorDigits[strings.ToUpper(currency)]; ok {
+		return digits
+	}
+	return 2
+}
+
+// ParseMoney parses a decimal amount in major units, such as "400.25",
+// exactly into minor units of the currency.
+func ParseMoney(amount string, currency string) (*branch.Money, error) {
+	if currency == "" {
+		currency = DefaultCurrency
+	}
+	currency = strings.ToUpper(currency)
+	digits := MinorDigits(currency)
+
+	text := strings.TrimSpace(amount)
+	negative := strings.HasPrefix(text, "-")
+	text = strings.TrimPrefix(strings.TrimPrefix(text, "-"), "+")
+	whole, fraction, _ := strings.Cut(text, ".")
+	if (whole == "" && fraction == "") || strings.Trim(whole+fraction, "0123456789") != "" {
+		return nil, fmt.Errorf("invalid amount %q", amount)
+	}
+	if len(fraction) > digits {
+		// Allow trailing zeros past the minor unit, as in 1.500 USD
+		if strings.Trim(fraction[digits:], "0") != "" {
+			return nil, fmt.Errorf("amount %q has more than %d decimal places for %s", amount, digits, currency)
+		}
+		fraction = fraction[:digits]
+	}
+	fraction += strings.Repeat("0", digits-len(fraction))
+
+	units, err := strconv.ParseInt(whole+fraction, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid amount %q: %v", amount, err)
+	}
+	if negative {
+		units = -units
+	}
+	return &branch.Money{CurrencyCode: currency, Units: units}, nil
+}
+
+// FormatMoney writes an amount in major units with the currency's number of
+// decimal places, such as "400.25".
+func FormatMoney(money *branch.Money) string {
+	digits := MinorDigits(money.GetCurrencyCode())
+	units := money.GetUnits()
+	sign := ""
+	if units < 0 {
+		sign = "-"
+	}
+	text := strconv.FormatUint(absUnits(units), 10)
+	if digits == 0 {
+		return sign + text
+	}
+	if len(text) <= digits {
+		text = strings.Repeat("0", digits-len(text)+1) + text
+	}
+	return sign + text[:len(text)-digits] + "." + text[len(text)-digits:]
+}
+
+func absUnits(units int64) uint64 {
+	if units < 0 {
+		return uint64(-(units + 1)) + 1
+	}
+	return uint64(units)
+}
+
+// addUnits adds two amounts of minor units, failing instead of overflowing.
+func addUnits(a, b int64) (int64, error) {
+	if (b > 0 && a > math.MaxInt64-b) || (b < 0 && a < math.MinInt64-b) {
+		return 0, status.Errorf(codes.OutOfRange, "balance would overflow")
+	}
+	return a + b, nil
+}
+
+// money wraps minor units of the branch's currency. The caller need not hold
+// s.mu, since the currency never changes.
+func (s *BranchServer) money(units int64) *branch.Money {
+	return &branch.Money{CurrencyCode: s.currency, Units: units}
+}
+
+// currencyCode normalizes the currency code of a request, which is the
+// branch's own currency if the request names none.
+func (s *BranchServer) currencyCode(code string) (string, error) {
+	if code == "" {
+		return s.currency, nil
+	}
+	code = strings.ToUpper(code)
+	if len(code) != 3 || strings.Trim(code, "ABCDEFGHIJKLMNOPQRSTUVWXYZ") != "" {
+		return "", status.Errorf(codes.InvalidArgument, "invalid currency code %q", code)
+	}
+	return code, nil
+}
+
+// amount checks that a request's amount is a non-negative amount of a valid
+// currency and returns it with its currency code normalized.
+func (s *BranchServer) amount(money *branch.Money) (*branch.Money, error) {
+	currency, err := s.currencyCode(money.GetCurrencyCode())
+	if err != nil {
+		return nil, err
+	}
+	if money.GetUnits() < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "amount must not be negative")
+	}
+	return &branch.Money{CurrencyCode: currency, Units: money.GetUnits()}, nil
+}
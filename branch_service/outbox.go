@@ -0,0 +1,348 @@
+package branch_service
+
+import (
+	"branch_service/branch"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultPropagateTimeout bounds a single attempt to deliver a write to a
+	// peer, unless the branch is configured otherwise.
+	DefaultPropagateTimeout = 5 * time.Second
+
+	// The delay before retrying a failed delivery starts at minRetryBackoff
+	// and doubles after every failure, up to maxRetryBackoff.
+	minRetryBackoff = 100 * time.Millisecond
+	maxRetryBackoff = 10 * time.Second
+)
+
+// errPeerDown is the delivery result of write events queued for a peer that
+// is believed dead.
+var errPeerDown = errors.New("peer is down")
+
+// deliveryResult is the outcome of the first attempt to deliver a write event
+// to a peer.
+type deliveryResult struct {
+	peerID int32
+	err    error
+}
+
+// delivery is a write event waiting in a peer's outbox. done receives the
+// result of the first attempt to deliver it, so the customer's request can
+// return while later attempts keep retrying in the background. done is shared
+// by every peer the write was queued for.
+type delivery struct {
+	write    *branch.WriteEvent
+	done     chan<- deliveryResult
+	reported bool
+}
+
+// outbox delivers write events to one peer in the order they were queued,
+// retrying each with exponential backoff until the peer accepts it. The queue
+// is kept in memory only: the branch's history holds every write until every
+// peer has synced it, so anti-entropy delivers what a restarted branch lost
+// from its outboxes.
+type outbox struct {
+	peerID  int32
+	client  branch.BranchServiceClient
+	timeout time.Duration // Deadline of a single delivery attempt
+
+	mu        sync.Mutex
+	queue     []*delivery
+	failing   error // Why the oldest write could not be delivered, if it could not
+	closed    bool  // Set once the peer has left the cluster
+	down      bool  // Set while the peer is believed dead
+	wake      chan struct{}
+	interrupt chan struct{} // Cuts a retry's backoff short when the peer leaves, goes down or comes back
+}
+
+func newOutbox(peerID int32, client branch.BranchServiceClient, timeout time.Duration) *outbox {
+	o := &outbox{
+		peerID:    peerID,
+		client:    client,
+		timeout:   timeout,
+		wake:      make(chan struct{}, 1),
+		interrupt: make(chan struct{}, 1),
+	}
+	go o.run()
+	return o
+}
+
+// enqueue queues a write event for the peer. The result of the first attempt
+// to deliver it is sent on done.
+func (o *outbox) enqueue(write *branch.WriteEvent, done chan<- deliveryResult) {
+	d := &delivery{write: write, done: done}
+	o.mu.Lock()
+	o.queue = append(o.queue, d)
+	if o.failing != nil {
+		// Don't keep the customer waiting behind a peer that is down
+		o.report(d, o.failing)
+	}
+	o.mu.Unlock()
+
+	select {
+	case o.wake <- struct{}{}:
+	default:
+	}
+}
+
+// report sends the result of the first delivery attempt of a write event.
+// The caller must hold o.mu.
+func (o *outbox) report(d *delivery, err error) {
+	if d.reported {
+		return
+	}
+	d.reported = true
+	d.done <- deliveryResult{peerID: o.peerID, err: err}
+}
+
+// close stops delivering to a peer that has left the cluster, and gives up
+// on the write events still queued for it.
+func (o *outbox) close() {
+	o.mu.Lock()
+	o.closed = true
+	for _, d := range o.queue {
+		o.report(d, fmt.Errorf("peer %d left the cluster", o.peerID))
+	}
+	o.queue = nil
+	o.mu.Unlock()
+
+	o.signal()
+}
+
+// drained reports whether every queued write event has been delivered.
+func (o *outbox) drained() bool {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return len(o.queue) == 0
+}
+
+// setDown stops delivering to a peer while it is believed dead, keeping
+// the queued write events for when it is alive again.
+func (o *outbox) setDown(down bool) {
+	o.mu.Lock()
+	o.down = down
+	if down {
+		o.failing = errPeerDown
+		for _, d := range o.queue {
+			o.report(d, o.failing)
+		}
+	}
+	o.mu.Unlock()
+
+	o.signal()
+}
+
+// signal wakes the sender, whether it is waiting for write events or for
+// its next retry.
+func (o *outbox) signal() {
+	select {
+	case o.wake <- struct{}{}:
+	default:
+	}
+	select {
+	case o.interrupt <- struct{}{}:
+	default:
+	}
+}
+
+// isDown reports whether the peer is believed dead.
+func (o *outbox) isDown() bool {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.down
+}
+
+func (o *outbox) isClosed() bool {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.closed
+}
+
+// head returns the oldest undelivered write event, if any and the peer is
+// not down.
+func (o *outbox) head() *delivery {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if len(o.queue) == 0 || o.down {
+		return nil
+	}
+	return o.queue[0]
+}
+
+// pop removes the oldest write event once the peer has accepted it.
+func (o *outbox) pop() {
+	o.mu.Lock()
+	if o.closed {
+		o.mu.Unlock()
+		return
+	}
+	o.report(o.queue[0], nil)
+	o.queue = o.queue[1:]
+	o.failing = nil
+	o.mu.Unlock()
+}
+
+// fail reports a failed delivery to every queued write event that has not
+// reported back yet, since none of them can be delivered before the oldest.
+func (o *outbox) fail(err error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.failing = err
+	for _, d := range o.queue {
+		o.report(d, err)
+	}
+}
+
+func (o *outbox) run() {
+	backoff := minRetryBackoff
+	for !o.isClosed() {
+		d := o.head()
+		if d == nil {
+			<-o.wake
+			continue
+		}
+
+		err := o.send(d.write)
+		if err == nil {
+			o.pop()
+			backoff = minRetryBackoff
+			continue
+		}
+		o.fail(err)
+
+		log.Printf("Failed to propagate write event %d of customer %d to peer %d, retrying in %v: %v", d.write.WriteEventID, d.write.CustomerId, o.peerID, backoff, err)
+		timer := time.NewTimer(backoff)
+		select {
+		case <-timer.C:
+		case <-o.interrupt:
+			timer.Stop()
+		}
+		backoff *= 2
+		if backoff > maxRetryBackoff {
+			backoff = maxRetryBackoff
+		}
+	}
+}
+
+// send makes one attempt to deliver a write event to the peer.
+func (o *outbox) send(write *branch.WriteEvent) error {
+	ctx, cancel := context.WithTimeout(context.Background(), o.timeout)
+	defer cancel()
+
+	var success bool
+	switch write.Kind {
+	case branch.WriteEvent_DEPOSIT:
+		response, err := o.client.PropagateDeposit(ctx, &branch.PropagateDepositRequest{
+			Money:        write.Money,
+			WriteEventID: write.WriteEventID,
+			CustomerId:   write.CustomerId,
+			OriginBranch: write.OriginBranch,
+			Vector:       write.Vector,
+			Balance:      write.Balance,
+			Timestamp:    write.Timestamp,
+		})
+		if err != nil {
+			return err
+		}
+		success = response.Success
+	case branch.WriteEvent_WITHDRAW:
+		response, err := o.client.PropagateWithdraw(ctx, &branch.PropagateWithdrawRequest{
+			Money:        write.Money,
+			WriteEventID: write.WriteEventID,
+			CustomerId:   write.CustomerId,
+			OriginBranch: write.OriginBranch,
+			Vector:       write.Vector,
+			Balance:      write.Balance,
+			Timestamp:    write.Timestamp,
+		})
+		if err != nil {
+			return err
+		}
+		success = response.Success
+	case branch.WriteEvent_CONVERT:
+		response, err := o.client.PropagateConvert(ctx, &branch.PropagateConvertRequest{
+			WriteEventID:     write.WriteEventID,
+			CustomerId:       write.CustomerId,
+			OriginBranch:     write.OriginBranch,
+			Vector:           write.Vector,
+			Money:            write.Money,
+			Converted:        write.Converted,
+			Balance:          write.Balance,
+			ConvertedBalance: write.ConvertedBalance,
+			Timestamp:        write.Timestamp,
+		})
+		if err != nil {
+			return err
+		}
+		success = response.Success
+	case branch.WriteEvent_TRANSFER:
+		response, err := o.client.PropagateTransfer(ctx, &branch.PropagateTransferRequest{
+			WriteEventID:     write.WriteEventID,
+			CustomerId:       write.CustomerId,
+			TargetCustomerId: write.TargetCustomerId,
+			OriginBranch:     write.OriginBranch,
+			Vector:           write.Vector,
+			Money:            write.Money,
+			Balance:          write.Balance,
+			Timestamp:        write.Timestamp,
+		})
+		if err != nil {
+			return err
+		}
+		success = response.Success
+	}
+	if !success {
+		return fmt.Errorf("peer %d rejected the write", o.peerID)
+	}
+	return nil
+}
+
+// enqueue queues a write event that originated here for every peer. It must
+// be called under the same hold of s.mu that applied the write, so that each
+// peer receives this branch's writes in the order they were applied. It
+// returns the channel receiving the first delivery attempts for awaitDelivery,
+// and how many peers the write was queued for.
+func (s *BranchServer) enqueue(write *branch.WriteEvent) (<-chan deliveryResult, int) {
+	done := make(chan deliveryResult, len(s.outboxes))
+	for _, o := range s.outboxes {
+		o.enqueue(write, done)
+	}
+	return done, len(s.outboxes)
+}
+
+// awaitDelivery waits for the first delivery attempts of a write event as
+// long as the propagation mode asks for, and returns how many peers accepted
+// it by then. Peers that could not be reached keep retrying in the background.
+func (s *BranchServer) awaitDelivery(write *branch.WriteEvent, done <-chan deliveryResult, peers int) int32 {
+	var needed int
+	switch s.PropagationMode {
+	case branch.PropagationMode_ASYNC:
+		return 0
+	case branch.PropagationMode_SYNC_QUORUM:
+		// A majority of the cluster, not counting this branch
+		needed = (peers + 1) / 2
+	default:
+		needed = peers
+	}
+
+	var acked int32
+	for answered := 0; answered < peers && int(acked) < needed; answered++ {
+		result := <-done
+		if result.err != nil {
+			// Gossip already logged that the peer is down
+			if result.err == errPeerDown {
+				continue
+			}
+			log.Printf("Write event %d of customer %d is queued for peer %d: %v", write.WriteEventID, write.CustomerId, result.peerID, result.err)
+			continue
+		}
+		acked++
+	}
+	return acked
+}
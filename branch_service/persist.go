@@ -0,0 +1,381 @@
+package branch_service
+
+import (
+	"branch_service/branch"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// DefaultSnapshotInterval is how often a branch with a data directory
+// snapshots its state, unless it is configured otherwise.
+const DefaultSnapshotInterval = time.Minute
+
+const snapshotName = "snapshot"
+
+// OpenDataDir makes the branch durable. It restores the state the branch
+// saved in dir before it was stopped, if any, and from then on logs every
+// change there before the change takes effect. It must be called after
+// EnableRaft and SetDedupWindow, and before the branch starts. A branch
+// that keeps its state in a store on disk does not need one.
+func (s *BranchServer) OpenDataDir(dir string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.store.(*memoryStore); !ok {
+		return fmt.Errorf("the branch already keeps its state in a store on disk")
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("error creating data directory: %v", err)
+	}
+
+	seq := int64(1)
+	snapshot, err := readSnapshot(dir)
+	if err != nil {
+		return err
+	}
+	if snapshot != nil {
+		if err := s.restore(snapshot); err != nil {
+			return err
+		}
+		seq = snapshot.WalSequence
+	}
+
+	// Replay the log written since the snapshot
+	seqs, err := walSequences(dir)
+	if err != nil {
+		return fmt.Errorf("error reading data directory: %v", err)
+	}
+	replayed := 0
+	for i, walSeq := range seqs {
+		if walSeq < seq {
+			continue
+		}
+		records, err := readWAL(filepath.Join(dir, walName(walSeq)), i == len(seqs)-1)
+		if err != nil {
+			return fmt.Errorf("error reading write-ahead log: %v", err)
+		}
+		for _, record := range records {
+			if err := s.replay(record); err != nil {
+				return err
+			}
+		}
+		replayed += len(records)
+		seq = walSeq
+	}
+
+	s.wal, err = openWAL(dir, seq)
+	if err != nil {
+		return fmt.Errorf("error opening write-ahead log: %v", err)
+	}
+	if snapshot != nil || replayed > 0 {
+		log.Printf("Branch %d restored %d write events from %s", s.ID, len(s.store.(*memoryStore).history), dir)
+	}
+	return nil
+}
+
+// restore loads a snapshot into a branch that has no state yet. The caller
+// must hold s.mu.
+func (s *BranchServer) restore(snapshot *branch.Snapshot) error {
+	for _, saved := range snapshot.Accounts {
+		if err := s.store.PutAccount(restoredAccount(saved)); err != nil {
+			return s.storeFailed(err)
+		}
+	}
+	for _, write := range snapshot.History {
+		if err := s.store.RecordApplied(write); err != nil {
+			return s.storeFailed(err)
+		}
+	}
+	if snapshot.HistoryBase != nil {
+		if err := s.store.CompactHistory(0, snapshot.HistoryBase); err != nil {
+			return s.storeFailed(err)
+		}
+	}
+	s.vector = VectorClock(snapshot.Vector)
+	if s.vector == nil {
+		s.vector = make(VectorClock)
+	}
+	for _, write := range snapshot.Dedup {
+		s.dedup.record(writeKey{CustomerID: write.CustomerId, EventID: write.WriteEventID}, resultOf(write))
+	}
+	for _, saved := range snapshot.Applied {
+		s.dedup.applied[saved.CustomerId] = &appliedEvents{watermark: saved.Watermark, tail: saved.Tail, forgotten: saved.Forgotten}
+	}
+
+	if snapshot.RaftState != nil || len(snapshot.RaftLog) > 0 {
+		if s.raft == nil {
+			return fmt.Errorf("the data directory was written in Raft mode")
+		}
+		r := s.raft
+		r.currentTerm = snapshot.RaftState.GetTerm()
+		r.votedFor = snapshot.RaftState.GetVotedFor()
+		r.log = append([]*branch.RaftEntry{{Term: snapshot.RaftLogBaseTerm}}, snapshot.RaftLog...)
+		r.logBase = snapshot.RaftLogBase
+		r.lastApplied = snapshot.RaftApplied
+		r.commitIndex = snapshot.RaftApplied
+	}
+	return nil
+}
+
+// replay redoes a change read back from the write-ahead log. The caller must
+// hold s.mu.
+func (s *BranchServer) replay(record *branch.WalRecord) error {
+	if (record.RaftApplied != 0 || record.RaftState != nil || record.RaftLog != nil) && s.raft == nil {
+		return fmt.Errorf("the data directory was written in Raft mode")
+	}
+	switch {
+	case record.Applied != nil:
+		if err := s.applyWrite(record.Applied); err != nil {
+			// It failed the same way when it was first applied
+			log.Printf("Branch %d skipped write event %d of customer %d from its log: %v", s.ID, record.Applied.WriteEventID, record.Applied.CustomerId, err)
+		}
+		if s.raft != nil {
+			s.raft.lastApplied = record.RaftApplied
+			s.raft.commitIndex = record.RaftApplied
+		}
+	case record.RaftState != nil:
+		s.raft.currentTerm = record.RaftState.Term
+		s.raft.votedFor = record.RaftState.VotedFor
+	case record.RaftLog != nil:
+		from := record.RaftLog.FromIndex
+		if from <= s.raft.logBase {
+			return fmt.Errorf("the write-ahead log replaces Raft log entry %d, which the snapshot dropped", from)
+		}
+		if from > s.raft.lastIndex()+1 {
+			return fmt.Errorf("the write-ahead log skips Raft log entries before index %d", from)
+		}
+		s.raft.log = append(s.raft.log[:from-s.raft.logBase], record.RaftLog.Entries...)
+	}
+	return nil
+}
+
+// logApplied logs a write event before it is applied. The caller must hold
+// s.mu.
+func (s *BranchServer) logApplied(write *branch.WriteEvent) error {
+	if s.wal == nil {
+		return nil
+	}
+	if s.raft != nil {
+		return s.persist(&branch.WalRecord{Applied: write, RaftApplied: s.raft.lastApplied})
+	}
+	if err := s.wal.append(&branch.WalRecord{Applied: write}); err != nil {
+		return status.Errorf(codes.Internal, "branch %d failed to log the write event: %v", s.ID, err)
+	}
+	return nil
+}
+
+// persistRaftState logs the branch's Raft term and vote. The caller must
+// hold s.mu.
+func (s *BranchServer) persistRaftState() error {
+	return s.persist(&branch.WalRecord{RaftState: &branch.RaftHardState{
+		Term:     s.raft.currentTerm,
+		VotedFor: s.raft.votedFor,
+	}})
+}
+
+// persistRaftLog logs the branch's Raft log from index from onwards. The
+// caller must hold s.mu.
+func (s *BranchServer) persistRaftLog(from int64) error {
+	return s.persist(&branch.WalRecord{RaftLog: &branch.RaftLogUpdate{
+		FromIndex: from,
+		Entries:   s.raft.log[from-s.raft.logBase:],
+	}})
+}
+
+// persist logs a change of a Raft branch before the branch acts on it. Raft
+// is only safe if such changes survive a restart, so a branch that cannot
+// log one steps down and takes no further part in the cluster: it neither
+// votes, stores entries nor applies them, and fails every request until it
+// is restarted. The caller must hold s.mu.
+func (s *BranchServer) persist(record *branch.WalRecord) error {
+	r := s.raft
+	if r.failed != nil {
+		return r.failed
+	}
+	if s.wal == nil {
+		return nil
+	}
+	if err := s.wal.append(record); err != nil {
+		log.Printf("Branch %d failed to write its write-ahead log, and stops taking part in Raft: %v", s.ID, err)
+		r.failed = status.Errorf(codes.Unavailable, "branch %d cannot write its write-ahead log: %v", s.ID, err)
+		r.role = raftFollower
+		r.leaderID = 0
+		// Wake requests waiting on entries this branch proposed as leader
+		s.notifyApplied()
+		return r.failed
+	}
+	return nil
+}
+
+// runSnapshots snapshots the branch's state on a timer until the branch
+// stops.
+func (s *BranchServer) runSnapshots() {
+	ticker := time.NewTicker(s.SnapshotInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+		case <-s.stop:
+			return
+		}
+		if err := s.takeSnapshot(false); err != nil {
+			log.Printf("Branch %d failed to take a snapshot: %v", s.ID, err)
+		}
+	}
+}
+
+// takeSnapshot saves the branch's whole state and starts a new log file,
+// then removes the log files the snapshot covers. In Raft mode it first
+// compacts the Raft log and the history, which anti-entropy compacts
+// otherwise. Unless force is set, it does nothing if the log is empty.
+func (s *BranchServer) takeSnapshot(force bool) error {
+	s.mu.Lock()
+	if s.wal.records == 0 && !force {
+		s.mu.Unlock()
+		return nil
+	}
+	if err := s.wal.rotate(); err != nil {
+		s.mu.Unlock()
+		return err
+	}
+	if s.raft != nil {
+		s.compactRaftLog()
+		if err := s.compactHistory(); err != nil {
+			s.mu.Unlock()
+			return err
+		}
+	}
+	dir, seq := s.wal.dir, s.wal.seq
+	snapshot, err := s.snapshotState(seq, true)
+	if err != nil {
+		s.mu.Unlock()
+		return err
+	}
+	data, err := proto.Marshal(snapshot)
+	s.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	// Replace the previous snapshot only once the new one is on disk
+	tmp := filepath.Join(dir, snapshotName+".tmp")
+	if err := writeSynced(tmp, data); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, filepath.Join(dir, snapshotName)); err != nil {
+		return err
+	}
+	if err := syncDir(dir); err != nil {
+		return err
+	}
+	return removeWALsBefore(dir, seq)
+}
+
+// snapshotState captures the branch's state up to the start of log file seq.
+// Without the history, the snapshot only sums the history up in its base. The
+// caller must hold s.mu.
+func (s *BranchServer) snapshotState(seq int64, withHistory bool) (*branch.Snapshot, error) {
+	snapshot := &branch.Snapshot{
+		WalSequence: seq,
+		Vector:      s.vector.Copy(),
+	}
+	err := s.store.Accounts(func(acc *Account) error {
+		snapshot.Accounts = append(snapshot.Accounts, &branch.AccountSnapshot{
+			CustomerId: acc.CustomerID,
+			Balances:   acc.balances(),
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, s.storeFailed(err)
+	}
+	if snapshot.HistoryBase, err = s.store.HistoryBase(); err != nil {
+		return nil, s.storeFailed(err)
+	}
+	if !withHistory {
+		snapshot.HistoryBase = copyBase(snapshot.HistoryBase)
+	}
+	err = s.store.History(func(write *branch.WriteEvent) error {
+		if withHistory {
+			snapshot.History = append(snapshot.History, write)
+		} else {
+			addToBase(snapshot.HistoryBase, write)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, s.storeFailed(err)
+	}
+	for _, key := range s.dedup.order {
+		result := s.dedup.results[key]
+		snapshot.Dedup = append(snapshot.Dedup, &branch.WriteEvent{
+			CustomerId:       key.CustomerID,
+			WriteEventID:     key.EventID,
+			Balance:          result.Balance,
+			Converted:        result.Converted,
+			ConvertedBalance: result.ConvertedBalance,
+			Vector:           result.Vector,
+		})
+	}
+	for customerID, events := range s.dedup.applied {
+		snapshot.Applied = append(snapshot.Applied, &branch.AppliedEvents{
+			CustomerId: customerID,
+			Watermark:  events.watermark,
+			Tail:       events.tail,
+			Forgotten:  events.forgotten,
+		})
+	}
+	sort.Slice(snapshot.Applied, func(i, j int) bool {
+		return snapshot.Applied[i].CustomerId < snapshot.Applied[j].CustomerId
+	})
+	if s.raft != nil {
+		r := s.raft
+		snapshot.RaftState = &branch.RaftHardState{Term: r.currentTerm, VotedFor: r.votedFor}
+		snapshot.RaftLog = r.log[1:]
+		snapshot.RaftLogBase = r.logBase
+		snapshot.RaftLogBaseTerm = r.log[0].Term
+		snapshot.RaftApplied = r.lastApplied
+	}
+	return snapshot, nil
+}
+
+// readSnapshot reads the snapshot in dir, or returns nil if there is none.
+func readSnapshot(dir string) (*branch.Snapshot, error) {
+	data, err := os.ReadFile(filepath.Join(dir, snapshotName))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading snapshot: %v", err)
+	}
+	snapshot := &branch.Snapshot{}
+	if err := proto.Unmarshal(data, snapshot); err != nil {
+		return nil, fmt.Errorf("error reading snapshot: %v", err)
+	}
+	return snapshot, nil
+}
+
+func writeSynced(filename string, data []byte) error {
+	file, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
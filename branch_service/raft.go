@@ -0,0 +1,789 @@
+package branch_service
+
+import (
+	"branch_service/branch"
+	"context"
+	"log"
+	"math/rand"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	raftTick              = 10 * time.Millisecond
+	raftHeartbeatInterval = 50 * time.Millisecond
+	raftMinElection       = 300 * time.Millisecond
+	raftMaxElection       = 600 * time.Millisecond
+	raftMaxBatch          = 100 // Most log entries sent in one AppendEntries call
+)
+
+type raftRole int
+
+const (
+	raftFollower raftRole = iota
+	raftCandidate
+	raftLeader
+)
+
+// applyResult is the outcome of applying a log entry that a request is
+// waiting on: the write as it was applied, or why it was rejected.
+type applyResult struct {
+	write *branch.WriteEvent
+	err   error
+}
+
+// raftState is a branch's part in the Raft cluster. It is guarded by the
+// branch's mu, like the rest of the branch state.
+type raftState struct {
+	role        raftRole
+	currentTerm int64
+	votedFor    int32 // 0 when the branch has not voted this term
+	leaderID    int32 // 0 when no leader is known
+
+	log         []*branch.RaftEntry // log[0] stands for the entry at logBase, so the others follow it
+	logBase     int64               // Index of the last entry dropped from the log, whose term log[0] keeps
+	commitIndex int64
+	lastApplied int64
+	heldByAll   int64 // Every branch holds the log up to here, as far as the leader last said
+
+	// Leader state
+	nextIndex   map[int32]int64
+	matchIndex  map[int32]int64
+	inFlight    map[int32]bool      // Peers with an AppendEntries call outstanding
+	lastAck     map[int32]time.Time // When the last call a peer accepted was sent
+	termStart   int64               // Index of the entry the leader started its term with
+	lastBeat    time.Time
+	waitingFor  map[int64]*applyResult // Results of the entries requests are waiting on
+	electionDue time.Time
+
+	failed error // Set once the branch could not write its log, which ends its part in the cluster
+}
+
+// EnableRaft switches the branch to the strongly consistent mode: writes are
+// appended to a Raft log replicated to every peer and applied in log order,
+// and balances are read linearizably from the leader. Followers forward
+// requests to the leader. It must be called before the branch starts, and
+// peers should be registered before then too, since a branch that knows no
+// peers elects itself.
+func (s *BranchServer) EnableRaft() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.raft = &raftState{
+		log:        []*branch.RaftEntry{{}},
+		nextIndex:  make(map[int32]int64),
+		matchIndex: make(map[int32]int64),
+		inFlight:   make(map[int32]bool),
+		lastAck:    make(map[int32]time.Time),
+		waitingFor: make(map[int64]*applyResult),
+	}
+	s.resetElectionTimer()
+}
+
+func (r *raftState) lastIndex() int64 {
+	return r.logBase + int64(len(r.log)-1)
+}
+
+func (r *raftState) entry(index int64) *branch.RaftEntry {
+	return r.log[index-r.logBase]
+}
+
+func (r *raftState) termAt(index int64) int64 {
+	if index < r.logBase || index > r.lastIndex() {
+		return -1
+	}
+	return r.entry(index).Term
+}
+
+// quorum is how many branches, counting this one, make a majority. The caller
+// must hold s.mu.
+func (s *BranchServer) quorum() int {
+	return (len(s.peers)+1)/2 + 1
+}
+
+// resetElectionTimer picks a new random election timeout. The caller must
+// hold s.mu.
+func (s *BranchServer) resetElectionTimer() {
+	timeout := raftMinElection + time.Duration(rand.Int63n(int64(raftMaxElection-raftMinElection)))
+	s.raft.electionDue = time.Now().Add(timeout)
+}
+
+// stepDown makes the branch a follower, adopting term if it is newer. The
+// caller must hold s.mu.
+func (s *BranchServer) stepDown(term int64) {
+	r := s.raft
+	if term > r.currentTerm {
+		r.currentTerm = term
+		r.votedFor = 0
+		// A failure has already made the branch a follower for good
+		s.persistRaftState()
+	}
+	if r.role != raftFollower {
+		r.role = raftFollower
+		// Wake requests waiting on entries this branch proposed as leader
+		s.notifyApplied()
+	}
+	s.resetElectionTimer()
+}
+
+// runRaft drives elections and heartbeats until the branch stops.
+func (s *BranchServer) runRaft() {
+	ticker := time.NewTicker(raftTick)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+		case <-s.stop:
+			return
+		}
+		s.mu.Lock()
+		now := time.Now()
+		switch {
+		case s.raft.failed != nil:
+			// The branch sits out until it is restarted
+		case s.raft.role == raftLeader:
+			if now.Sub(s.raft.lastBeat) >= raftHeartbeatInterval {
+				s.replicate()
+			}
+		case now.After(s.raft.electionDue):
+			s.startElection()
+		}
+		s.mu.Unlock()
+	}
+}
+
+// startElection makes the branch a candidate for the next term and asks every
+// peer for its vote. The caller must hold s.mu.
+func (s *BranchServer) startElection() {
+	r := s.raft
+	r.role = raftCandidate
+	r.currentTerm++
+	r.votedFor = s.ID
+	r.leaderID = 0
+	if err := s.persistRaftState(); err != nil {
+		return
+	}
+	s.resetElectionTimer()
+
+	request := &branch.RequestVoteRequest{
+		Term:         r.currentTerm,
+		CandidateId:  s.ID,
+		LastLogIndex: r.lastIndex(),
+		LastLogTerm:  r.termAt(r.lastIndex()),
+	}
+	votes := 1
+	if votes >= s.quorum() {
+		s.becomeLeader()
+		return
+	}
+	for peerID, client := range s.peers {
+		go func(peerID int32, client branch.BranchServiceClient) {
+			ctx, cancel := context.WithTimeout(context.Background(), raftMinElection)
+			defer cancel()
+			response, err := client.RequestVote(ctx, request)
+			if err != nil {
+				return
+			}
+
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			if response.Term > r.currentTerm {
+				s.stepDown(response.Term)
+				return
+			}
+			if r.role != raftCandidate || r.currentTerm != request.Term || !response.VoteGranted {
+				return
+			}
+			votes++
+			if votes >= s.quorum() {
+				s.becomeLeader()
+			}
+		}(peerID, client)
+	}
+}
+
+// becomeLeader takes over as leader for the current term and starts it with
+// an empty entry, which lets it learn which entries are committed. The caller
+// must hold s.mu.
+func (s *BranchServer) becomeLeader() {
+	r := s.raft
+	r.role = raftLeader
+	r.leaderID = s.ID
+	for peerID := range s.peers {
+		r.nextIndex[peerID] = r.lastIndex() + 1
+		r.matchIndex[peerID] = 0
+	}
+	r.log = append(r.log, &branch.RaftEntry{Term: r.currentTerm})
+	r.termStart = r.lastIndex()
+	if err := s.persistRaftLog(r.termStart); err != nil {
+		return
+	}
+	log.Printf("Branch %d is the Raft leader for term %d", s.ID, r.currentTerm)
+	s.replicate()
+}
+
+// replicate sends every peer without an outstanding call the log entries it
+// is missing, or a heartbeat if it has them all. The caller must hold s.mu.
+func (s *BranchServer) replicate() {
+	r := s.raft
+	r.lastBeat = time.Now()
+	for peerID := range s.peers {
+		s.replicateTo(peerID)
+	}
+	// A branch without peers commits on its own
+	s.advanceCommit()
+}
+
+// replicateTo sends one peer the log entries it is missing. The caller must
+// hold s.mu.
+func (s *BranchServer) replicateTo(peerID int32) {
+	r := s.raft
+	if r.inFlight[peerID] {
+		return
+	}
+	next := r.nextIndex[peerID]
+	if next <= r.logBase {
+		// Every peer held the entries the log dropped, so this one lost its log
+		log.Printf("Branch %d no longer has the Raft log entries peer %d is missing from index %d", s.ID, peerID, next)
+		next = r.logBase + 1
+	}
+	end := r.lastIndex() + 1
+	if end-next > raftMaxBatch {
+		end = next + raftMaxBatch
+	}
+	request := &branch.AppendEntriesRequest{
+		Term:         r.currentTerm,
+		LeaderId:     s.ID,
+		PrevLogIndex: next - 1,
+		PrevLogTerm:  r.termAt(next - 1),
+		Entries:      append([]*branch.RaftEntry(nil), r.log[next-r.logBase:end-r.logBase]...),
+		LeaderCommit: r.commitIndex,
+		HeldByAll:    s.heldByAll(),
+	}
+	client := s.peers[peerID]
+	r.inFlight[peerID] = true
+	sent := time.Now()
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), s.PropagateTimeout)
+		defer cancel()
+		response, err := client.AppendEntries(ctx, request)
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		r.inFlight[peerID] = false
+		if err != nil {
+			return
+		}
+		if response.Term > r.currentTerm {
+			s.stepDown(response.Term)
+			return
+		}
+		if r.role != raftLeader || r.currentTerm != request.Term {
+			return
+		}
+
+		if !response.Success {
+			// Back up to the end of the peer's log, or one entry at a time
+			next := r.nextIndex[peerID] - 1
+			if response.LastLogIndex+1 < next {
+				next = response.LastLogIndex + 1
+			}
+			if next < 1 {
+				next = 1
+			}
+			r.nextIndex[peerID] = next
+			// A peer missing dropped entries waits for the next heartbeat
+			if next > r.logBase {
+				s.replicateTo(peerID)
+			}
+			return
+		}
+
+		r.lastAck[peerID] = sent
+		match := request.PrevLogIndex + int64(len(request.Entries))
+		if match > r.matchIndex[peerID] {
+			r.matchIndex[peerID] = match
+		}
+		r.nextIndex[peerID] = match + 1
+		s.advanceCommit()
+		// Wake reads waiting to confirm leadership
+		s.notifyApplied()
+		if r.nextIndex[peerID] <= r.lastIndex() {
+			s.replicateTo(peerID)
+		}
+	}()
+}
+
+// advanceCommit commits the newest entry of the current term stored on a
+// majority of branches, and everything before it. The caller must hold s.mu.
+func (s *BranchServer) advanceCommit() {
+	r := s.raft
+	for index := r.lastIndex(); index > r.commitIndex; index-- {
+		if r.entry(index).Term != r.currentTerm {
+			break
+		}
+		count := 1
+		for _, match := range r.matchIndex {
+			if match >= index {
+				count++
+			}
+		}
+		if count >= s.quorum() {
+			r.commitIndex = index
+			s.applyCommitted()
+			return
+		}
+	}
+}
+
+// heldByAll returns the index up to which every branch holds the log, as far
+// as this branch knows. The caller must hold s.mu.
+func (s *BranchServer) heldByAll() int64 {
+	r := s.raft
+	if r.role != raftLeader {
+		return r.heldByAll
+	}
+	index := r.lastIndex()
+	for peerID := range s.peers {
+		index = min(index, r.matchIndex[peerID])
+	}
+	return max(index, r.heldByAll)
+}
+
+// compactRaftLog drops the log entries this branch has applied, once every
+// branch holds them, so that whichever branch leads next can still bring
+// every peer up to date. Entries requests are waiting on stay until the
+// requests have their results. The caller must hold s.mu.
+func (s *BranchServer) compactRaftLog() {
+	r := s.raft
+	upTo := min(r.lastApplied, s.heldByAll())
+	for index := range r.waitingFor {
+		upTo = min(upTo, index-1)
+	}
+	if upTo <= r.logBase {
+		return
+	}
+	kept := []*branch.RaftEntry{{Term: r.termAt(upTo)}}
+	r.log = append(kept, r.log[upTo+1-r.logBase:]...)
+	r.logBase = upTo
+}
+
+// applyCommitted applies committed log entries in log order. The caller must
+// hold s.mu.
+func (s *BranchServer) applyCommitted() {
+	r := s.raft
+	for r.lastApplied < r.commitIndex && r.failed == nil {
+		r.lastApplied++
+		entry := r.entry(r.lastApplied)
+		if entry.Write == nil {
+			continue
+		}
+		result := s.applyLogged(entry.Write)
+		if r.failed != nil {
+			// The entry could not be logged, so it was not applied
+			r.lastApplied--
+			break
+		}
+		if _, waiting := r.waitingFor[r.lastApplied]; waiting {
+			r.waitingFor[r.lastApplied] = result
+		}
+	}
+	s.notifyApplied()
+}
+
+// applyLogged applies a write taken from the log. Every branch applies the
+// same entries in the same order, so every branch reaches the same decision
+// on a withdrawal and stamps the write with the same vector clock. The
+// caller must hold s.mu.
+func (s *BranchServer) applyLogged(logged *branch.WriteEvent) *applyResult {
+	write := proto.Clone(logged).(*branch.WriteEvent)
+	if result, ok := s.replayed(write.CustomerId, write.WriteEventID); ok {
+		if result.Forgotten {
+			return &applyResult{err: errForgotten(write.CustomerId, write.WriteEventID)}
+		}
+		write.Balance = result.Balance
+		write.Converted = result.Converted
+		write.ConvertedBalance = result.ConvertedBalance
+		write.Vector = result.Vector
+		return &applyResult{write: write}
+	}
+
+	if err := s.settle(write); err != nil {
+		return &applyResult{err: err}
+	}
+	write.Vector = s.vector.Copy()
+	write.Vector[write.OriginBranch]++
+	if err := s.applyWrite(write); err != nil {
+		return &applyResult{err: err}
+	}
+	return &applyResult{write: write}
+}
+
+// raftWrite appends a write to the log as leader and waits until it has been
+// committed and applied. The caller must hold s.mu.
+func (s *BranchServer) raftWrite(ctx context.Context, write *branch.WriteEvent) (*branch.WriteEvent, error) {
+	r := s.raft
+	if r.role != raftLeader {
+		return nil, status.Errorf(codes.Unavailable, "branch %d is not the Raft leader", s.ID)
+	}
+	write.OriginBranch = s.ID
+	write.Timestamp = time.Now().UnixMilli()
+	r.log = append(r.log, &branch.RaftEntry{Term: r.currentTerm, Write: write})
+	index, term := r.lastIndex(), r.currentTerm
+	if err := s.persistRaftLog(index); err != nil {
+		return nil, err
+	}
+	r.waitingFor[index] = nil
+	defer delete(r.waitingFor, index)
+	s.replicate()
+
+	err := s.waitUntil(ctx, func() bool {
+		return r.lastApplied >= index || r.termAt(index) != term
+	})
+	if err != nil {
+		return nil, err
+	}
+	if r.termAt(index) != term || r.waitingFor[index] == nil {
+		return nil, status.Errorf(codes.Unavailable, "branch %d lost the Raft leadership before the write committed", s.ID)
+	}
+	result := r.waitingFor[index]
+	return result.write, result.err
+}
+
+// raftRead waits until the leader can serve a linearizable read: it has
+// committed an entry of its own term, confirmed with a majority that it is
+// still the leader, and applied everything committed when the read arrived.
+// The caller must hold s.mu.
+func (s *BranchServer) raftRead(ctx context.Context) error {
+	r := s.raft
+	if r.role != raftLeader {
+		return status.Errorf(codes.Unavailable, "branch %d is not the Raft leader", s.ID)
+	}
+	term := r.currentTerm
+	if err := s.waitUntil(ctx, func() bool { return r.currentTerm != term || r.commitIndex >= r.termStart }); err != nil {
+		return err
+	}
+
+	readIndex := r.commitIndex
+	start := time.Now()
+	s.replicate()
+	err := s.waitUntil(ctx, func() bool {
+		if r.currentTerm != term || r.role != raftLeader {
+			return true
+		}
+		acks := 1
+		for _, sent := range r.lastAck {
+			if !sent.Before(start) {
+				acks++
+			}
+		}
+		return acks >= s.quorum() && r.lastApplied >= readIndex
+	})
+	if err != nil {
+		return err
+	}
+	if r.currentTerm != term || r.role != raftLeader {
+		return status.Errorf(codes.Unavailable, "branch %d lost the Raft leadership during the read", s.ID)
+	}
+	return nil
+}
+
+// raftLeaderClient returns the client of the leader to forward a request to,
+// or nil if this branch is the leader. The caller must hold s.mu.
+func (s *BranchServer) raftLeaderClient(forwardedBy int32) (branch.BranchServiceClient, error) {
+	r := s.raft
+	if r.failed != nil {
+		return nil, r.failed
+	}
+	if r.role == raftLeader {
+		return nil, nil
+	}
+	if forwardedBy != 0 {
+		return nil, status.Errorf(codes.Unavailable, "branch %d is no longer the Raft leader", s.ID)
+	}
+	client, ok := s.peers[r.leaderID]
+	if !ok {
+		return nil, status.Errorf(codes.Unavailable, "branch %d knows no Raft leader", s.ID)
+	}
+	return client, nil
+}
+
+// RequestVote answers a candidate asking for this branch's vote.
+func (s *BranchServer) RequestVote(ctx context.Context, request *branch.RequestVoteRequest) (*branch.RequestVoteResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.raft == nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "branch %d is not in Raft mode", s.ID)
+	}
+	r := s.raft
+	if r.failed != nil {
+		return nil, r.failed
+	}
+	if request.Term > r.currentTerm {
+		s.stepDown(request.Term)
+	}
+
+	// Only vote for candidates whose log is at least as up to date as ours
+	lastTerm := r.termAt(r.lastIndex())
+	upToDate := request.LastLogTerm > lastTerm ||
+		(request.LastLogTerm == lastTerm && request.LastLogIndex >= r.lastIndex())
+	granted := request.Term == r.currentTerm && upToDate &&
+		(r.votedFor == 0 || r.votedFor == request.CandidateId)
+	if granted {
+		r.votedFor = request.CandidateId
+		if err := s.persistRaftState(); err != nil {
+			return nil, err
+		}
+		s.resetElectionTimer()
+	}
+	return &branch.RequestVoteResponse{
+		Term:        r.currentTerm,
+		VoteGranted: granted,
+	}, nil
+}
+
+// AppendEntries stores log entries sent by the leader, and serves as its
+// heartbeat.
+func (s *BranchServer) AppendEntries(ctx context.Context, request *branch.AppendEntriesRequest) (*branch.AppendEntriesResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.raft == nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "branch %d is not in Raft mode", s.ID)
+	}
+	r := s.raft
+	if r.failed != nil {
+		return nil, r.failed
+	}
+	if request.Term < r.currentTerm {
+		return &branch.AppendEntriesResponse{Term: r.currentTerm, LastLogIndex: r.lastIndex()}, nil
+	}
+	s.stepDown(request.Term)
+	r.leaderID = request.LeaderId
+
+	// Entries up to the log's base were committed, so they match the leader's
+	prevIndex, prevTerm, entries := request.PrevLogIndex, request.PrevLogTerm, request.Entries
+	if prevIndex < r.logBase {
+		entries = entries[min(r.logBase-prevIndex, int64(len(entries))):]
+		prevIndex, prevTerm = r.logBase, r.termAt(r.logBase)
+	}
+
+	// The entry before the new ones must match the leader's
+	if prevIndex > r.lastIndex() || r.termAt(prevIndex) != prevTerm {
+		lastIndex := r.lastIndex()
+		if prevIndex <= lastIndex {
+			lastIndex = prevIndex - 1
+		}
+		return &branch.AppendEntriesResponse{Term: r.currentTerm, LastLogIndex: lastIndex}, nil
+	}
+
+	var changedFrom int64
+	for i, entry := range entries {
+		index := prevIndex + 1 + int64(i)
+		if index <= r.lastIndex() {
+			if r.entry(index).Term == entry.Term {
+				continue
+			}
+			// Drop the conflicting entry and everything after it
+			r.log = r.log[:index-r.logBase]
+		}
+		r.log = append(r.log, entry)
+		if changedFrom == 0 {
+			changedFrom = index
+		}
+	}
+	if changedFrom != 0 {
+		if err := s.persistRaftLog(changedFrom); err != nil {
+			return nil, err
+		}
+	}
+
+	// Commit what the leader has committed, as far as it matches our log
+	commit := request.LeaderCommit
+	if lastNew := request.PrevLogIndex + int64(len(request.Entries)); lastNew < commit {
+		commit = lastNew
+	}
+	if commit > r.commitIndex {
+		r.commitIndex = commit
+		s.applyCommitted()
+	}
+	r.heldByAll = max(r.heldByAll, request.HeldByAll)
+	return &branch.AppendEntriesResponse{
+		Term:         r.currentTerm,
+		Success:      true,
+		LastLogIndex: r.lastIndex(),
+	}, nil
+}
+
+// raftDeposit makes a deposit through the Raft log, forwarding it to the
+// leader if this branch is a follower.
+func (s *BranchServer) raftDeposit(ctx context.Context, request *branch.DepositRequest, money *branch.Money) (*branch.DepositResponse, error) {
+	s.mu.Lock()
+	leader, err := s.raftLeaderClient(request.ForwardedBy)
+	if err != nil || leader != nil {
+		s.mu.Unlock()
+		if err != nil {
+			return nil, err
+		}
+		forwarded := proto.Clone(request).(*branch.DepositRequest)
+		forwarded.ForwardedBy = s.ID
+		return leader.Deposit(ctx, forwarded)
+	}
+	defer s.mu.Unlock()
+
+	write, err := s.raftWrite(ctx, &branch.WriteEvent{
+		Kind:         branch.WriteEvent_DEPOSIT,
+		CustomerId:   request.CustomerId,
+		WriteEventID: request.WriteEventID,
+		Money:        money,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &branch.DepositResponse{
+		Balance:    write.Balance,
+		Vector:     write.Vector,
+		PeersAcked: int32(s.quorum() - 1),
+	}, nil
+}
+
+// raftWithdraw makes a withdrawal through the Raft log, forwarding it to the
+// leader if this branch is a follower. Whether the account covers it is
+// decided when the entry is applied, in log order.
+func (s *BranchServer) raftWithdraw(ctx context.Context, request *branch.WithdrawRequest, money *branch.Money) (*branch.WithdrawResponse, error) {
+	s.mu.Lock()
+	leader, err := s.raftLeaderClient(request.ForwardedBy)
+	if err != nil || leader != nil {
+		s.mu.Unlock()
+		if err != nil {
+			return nil, err
+		}
+		forwarded := proto.Clone(request).(*branch.WithdrawRequest)
+		forwarded.ForwardedBy = s.ID
+		return leader.Withdraw(ctx, forwarded)
+	}
+	defer s.mu.Unlock()
+
+	write, err := s.raftWrite(ctx, &branch.WriteEvent{
+		Kind:         branch.WriteEvent_WITHDRAW,
+		CustomerId:   request.CustomerId,
+		WriteEventID: request.WriteEventID,
+		Money:        money,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &branch.WithdrawResponse{
+		Balance:    write.Balance,
+		Vector:     write.Vector,
+		PeersAcked: int32(s.quorum() - 1),
+	}, nil
+}
+
+// raftConvert makes a currency conversion through the Raft log, forwarding it
+// to the leader if this branch is a follower. The leader quotes the rate, so
+// the log carries the amount every branch credits.
+func (s *BranchServer) raftConvert(ctx context.Context, request *branch.ConvertCurrencyRequest, money *branch.Money, to string) (*branch.ConvertCurrencyResponse, error) {
+	s.mu.Lock()
+	leader, err := s.raftLeaderClient(request.ForwardedBy)
+	s.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	if leader != nil {
+		forwarded := proto.Clone(request).(*branch.ConvertCurrencyRequest)
+		forwarded.ForwardedBy = s.ID
+		return leader.ConvertCurrency(ctx, forwarded)
+	}
+
+	converted, err := s.quote(ctx, money, to)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	write, err := s.raftWrite(ctx, &branch.WriteEvent{
+		Kind:         branch.WriteEvent_CONVERT,
+		CustomerId:   request.CustomerId,
+		WriteEventID: request.WriteEventID,
+		Money:        money,
+		Converted:    converted,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &branch.ConvertCurrencyResponse{
+		Balance:          write.Balance,
+		Converted:        write.Converted,
+		ConvertedBalance: write.ConvertedBalance,
+		Vector:           write.Vector,
+		PeersAcked:       int32(s.quorum() - 1),
+	}, nil
+}
+
+// raftTransfer makes a transfer through the Raft log, forwarding it to the
+// leader if this branch is a follower. Whether the sending account covers it
+// is decided when the entry is applied, in log order.
+func (s *BranchServer) raftTransfer(ctx context.Context, request *branch.TransferRequest, money *branch.Money) (*branch.TransferResponse, error) {
+	s.mu.Lock()
+	leader, err := s.raftLeaderClient(request.ForwardedBy)
+	if err != nil || leader != nil {
+		s.mu.Unlock()
+		if err != nil {
+			return nil, err
+		}
+		forwarded := proto.Clone(request).(*branch.TransferRequest)
+		forwarded.ForwardedBy = s.ID
+		return leader.Transfer(ctx, forwarded)
+	}
+	defer s.mu.Unlock()
+
+	write, err := s.raftWrite(ctx, &branch.WriteEvent{
+		Kind:             branch.WriteEvent_TRANSFER,
+		CustomerId:       request.CustomerId,
+		WriteEventID:     request.WriteEventID,
+		Money:            money,
+		TargetCustomerId: request.TargetCustomerId,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &branch.TransferResponse{
+		Balance:    write.Balance,
+		Vector:     write.Vector,
+		PeersAcked: int32(s.quorum() - 1),
+	}, nil
+}
+
+// raftQueryBalance reads a balance linearizably at the leader, forwarding the
+// query to it if this branch is a follower.
+func (s *BranchServer) raftQueryBalance(ctx context.Context, request *branch.QueryBalanceRequest, currency string) (*branch.QueryBalanceResponse, error) {
+	s.mu.Lock()
+	leader, err := s.raftLeaderClient(request.ForwardedBy)
+	if err != nil || leader != nil {
+		s.mu.Unlock()
+		if err != nil {
+			return nil, err
+		}
+		forwarded := proto.Clone(request).(*branch.QueryBalanceRequest)
+		forwarded.ForwardedBy = s.ID
+		return leader.QueryBalance(ctx, forwarded)
+	}
+	defer s.mu.Unlock()
+
+	if err := s.raftRead(ctx); err != nil {
+		return nil, err
+	}
+	acc, err := s.account(request.CustomerId)
+	if err != nil {
+		return nil, err
+	}
+	return &branch.QueryBalanceResponse{
+		Money:    acc.balance(currency),
+		Balances: acc.balances(),
+		Vector:   s.vector.Copy(),
+	}, nil
+}
@@ -0,0 +1,105 @@
+package branch_service
+
+import (
+	"branch_service/branch"
+	"context"
+	"testing"
+	"time"
+)
+
+// TestRaftFailover runs a Raft cluster of three branches that snapshot often,
+// so they compact their logs, stops the leader, and checks that the two
+// branches left elect a new leader, which takes writes and serves
+// linearizable reads at either branch.
+func TestRaftFailover(t *testing.T) {
+	servers, clients := startTestBranches(t, 3, 0, func(s *BranchServer) {
+		s.EnableRaft()
+		if err := s.OpenDataDir(t.TempDir()); err != nil {
+			t.Fatalf("opening the data directory: %v", err)
+		}
+		s.SnapshotInterval = 20 * time.Millisecond
+	})
+	connectTestBranches(servers, clients)
+	ctx := context.Background()
+
+	// deposit retries a deposit until some branch is the leader, and returns
+	// the balance it left
+	deposit := func(client branch.BranchServiceClient, eventID int32) int64 {
+		t.Helper()
+		deadline := time.Now().Add(10 * time.Second)
+		for {
+			response, err := client.Deposit(ctx, &branch.DepositRequest{CustomerId: 1, WriteEventID: eventID, Money: &branch.Money{CurrencyCode: "USD", Units: 1}})
+			if err == nil {
+				return response.Balance.GetUnits()
+			}
+			if time.Now().After(deadline) {
+				t.Fatalf("deposit %d: %v", eventID, err)
+			}
+			time.Sleep(20 * time.Millisecond)
+		}
+	}
+	// query reads the balance once a leader is known, and fails the test if
+	// it misses a deposit that returned
+	query := func(client branch.BranchServiceClient, want int64) {
+		t.Helper()
+		deadline := time.Now().Add(10 * time.Second)
+		for {
+			response, err := client.QueryBalance(ctx, &branch.QueryBalanceRequest{CustomerId: 1})
+			if err == nil {
+				if units := response.Money.GetUnits(); units != want {
+					t.Fatalf("read %d after the deposit that left %d", units, want)
+				}
+				return
+			}
+			if time.Now().After(deadline) {
+				t.Fatalf("querying the balance: %v", err)
+			}
+			time.Sleep(20 * time.Millisecond)
+		}
+	}
+	compacted := func(s *BranchServer) bool {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		return s.raft.logBase > 0
+	}
+
+	eventID := int32(0)
+	for i := 0; i < 20; i++ {
+		eventID++
+		balance := deposit(clients[i%3], eventID)
+		query(clients[(i+1)%3], balance)
+	}
+	deadline := time.Now().Add(10 * time.Second)
+	for _, s := range servers {
+		for !compacted(s) {
+			if time.Now().After(deadline) {
+				t.Fatalf("branch %d never compacted its Raft log", s.ID)
+			}
+			time.Sleep(20 * time.Millisecond)
+		}
+	}
+
+	var survivors []branch.BranchServiceClient
+	for i, s := range servers {
+		s.mu.Lock()
+		leader := s.raft.role == raftLeader
+		s.mu.Unlock()
+		if !leader {
+			survivors = append(survivors, clients[i])
+			continue
+		}
+		stopCtx, cancel := context.WithTimeout(ctx, time.Second)
+		s.Stop(stopCtx)
+		cancel()
+	}
+	if len(survivors) != 2 {
+		t.Fatalf("%d branches were not the leader, want 2", len(survivors))
+	}
+
+	for i := 0; i < 20; i++ {
+		eventID++
+		balance := deposit(survivors[i%2], eventID)
+		query(survivors[(i+1)%2], balance)
+	}
+	awaitBalances(t, survivors, map[int32]int64{1: int64(eventID)}, 0)
+}
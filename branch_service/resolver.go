@@ -0,0 +1,319 @@
+package branch_service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/resolver"
+)
+
+const (
+	// ResolverScheme is the gRPC scheme of branch targets, which dial
+	// branch N as "branch:///N" through a resolver from NewGRPCResolver.
+	ResolverScheme = "branch"
+
+	// DefaultDNSInterval is how often a DNSResolver looks up the records of
+	// a branch it watches again, and DefaultRegistryPollInterval how often a
+	// FileRegistry checks a branch's file for changes.
+	DefaultDNSInterval          = 30 * time.Second
+	DefaultRegistryPollInterval = time.Second
+)
+
+// Resolver finds the addresses branches serve at.
+type Resolver interface {
+	// BranchIDs lists the IDs of the branches the resolver knows.
+	BranchIDs(ctx context.Context) ([]int32, error)
+
+	// Resolve returns the address the branch serves at.
+	Resolve(ctx context.Context, id int32) (string, error)
+
+	// Watch calls update with the branch's address, or the error resolving
+	// it, and again whenever that changes, until ctx ends. Watch may return
+	// after the first call if the address never changes.
+	Watch(ctx context.Context, id int32, update func(address string, err error))
+}
+
+// OpenResolver opens a resolver from a spec naming its kind and argument:
+// "file:path" for a static cluster config, "dns:domain" for DNS SRV records,
+// or "registry:dir" for a FileRegistry.
+func OpenResolver(spec string) (Resolver, error) {
+	kind, arg, ok := strings.Cut(spec, ":")
+	if !ok || arg == "" {
+		return nil, fmt.Errorf("resolver %q is not of the form kind:argument", spec)
+	}
+	switch kind {
+	case "file":
+		return LoadClusterConfig(arg)
+	case "dns":
+		return NewDNSResolver(arg), nil
+	case "registry":
+		return NewFileRegistry(arg), nil
+	default:
+		return nil, fmt.Errorf("unknown resolver kind %q, expected file, dns or registry", kind)
+	}
+}
+
+// BranchTarget is the gRPC target that dials the branch through a resolver
+// from NewGRPCResolver.
+func BranchTarget(id int32) string {
+	return fmt.Sprintf("%s:///%d", ResolverScheme, id)
+}
+
+func (c *ClusterConfig) BranchIDs(ctx context.Context) ([]int32, error) {
+	ids := make([]int32, 0, len(c.Branches))
+	for _, b := range c.Branches {
+		ids = append(ids, b.ID)
+	}
+	return ids, nil
+}
+
+func (c *ClusterConfig) Resolve(ctx context.Context, id int32) (string, error) {
+	b, err := c.Branch(id)
+	if err != nil {
+		return "", err
+	}
+	return b.Address(), nil
+}
+
+func (c *ClusterConfig) Watch(ctx context.Context, id int32, update func(address string, err error)) {
+	update(c.Resolve(ctx, id))
+}
+
+// DNSResolver finds branches by the SRV records of the "branch" service
+// over TCP under a domain, _branch._tcp.<domain>. Each record's target host
+// names the branch in its first label, as in branch-3.bank.example.
+type DNSResolver struct {
+	Domain   string
+	Interval time.Duration // How often Watch looks the records up again
+	Lookup   *net.Resolver
+}
+
+func NewDNSResolver(domain string) *DNSResolver {
+	return &DNSResolver{
+		Domain:   domain,
+		Interval: DefaultDNSInterval,
+		Lookup:   net.DefaultResolver,
+	}
+}
+
+// records returns the address of every branch with an SRV record, taking
+// the first record of each in the order of their priority and weight.
+func (d *DNSResolver) records(ctx context.Context) (map[int32]string, error) {
+	_, srvs, err := d.Lookup.LookupSRV(ctx, ResolverScheme, "tcp", d.Domain)
+	if err != nil {
+		return nil, err
+	}
+	addresses := make(map[int32]string)
+	for _, srv := range srvs {
+		host := strings.TrimSuffix(srv.Target, ".")
+		label, _, _ := strings.Cut(host, ".")
+		idText, ok := strings.CutPrefix(label, "branch-")
+		if !ok {
+			continue
+		}
+		id, err := strconv.ParseInt(idText, 10, 32)
+		if err != nil {
+			continue
+		}
+		if _, ok := addresses[int32(id)]; !ok {
+			addresses[int32(id)] = net.JoinHostPort(host, strconv.Itoa(int(srv.Port)))
+		}
+	}
+	return addresses, nil
+}
+
+func (d *DNSResolver) BranchIDs(ctx context.Context) ([]int32, error) {
+	addresses, err := d.records(ctx)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]int32, 0, len(addresses))
+	for id := range addresses {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids, nil
+}
+
+func (d *DNSResolver) Resolve(ctx context.Context, id int32) (string, error) {
+	addresses, err := d.records(ctx)
+	if err != nil {
+		return "", err
+	}
+	address, ok := addresses[id]
+	if !ok {
+		return "", fmt.Errorf("branch %d has no SRV record under %s", id, d.Domain)
+	}
+	return address, nil
+}
+
+func (d *DNSResolver) Watch(ctx context.Context, id int32, update func(address string, err error)) {
+	pollWatch(ctx, d.Interval, func() (string, error) { return d.Resolve(ctx, id) }, update)
+}
+
+// FileRegistry is a registry of branch addresses in a local directory that
+// every branch registers itself in, with one file per branch. Watching a
+// branch polls its file, so a branch that moves is found again without
+// restarting the branches and customers that reach it.
+type FileRegistry struct {
+	Dir          string
+	PollInterval time.Duration // How often Watch checks a branch's file
+}
+
+func NewFileRegistry(dir string) *FileRegistry {
+	return &FileRegistry{
+		Dir:          dir,
+		PollInterval: DefaultRegistryPollInterval,
+	}
+}
+
+func (f *FileRegistry) path(id int32) string {
+	return filepath.Join(f.Dir, fmt.Sprintf("branch-%d.json", id))
+}
+
+// Register records where the branch serves, replacing the file atomically
+// so that watchers never read half of it.
+func (f *FileRegistry) Register(b ClusterBranch) error {
+	if err := os.MkdirAll(f.Dir, 0755); err != nil {
+		return err
+	}
+	data, err := json.Marshal(b)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(f.Dir, ".branch-*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), f.path(b.ID))
+}
+
+// Deregister removes the branch from the registry.
+func (f *FileRegistry) Deregister(id int32) error {
+	err := os.Remove(f.path(id))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
+
+func (f *FileRegistry) BranchIDs(ctx context.Context) ([]int32, error) {
+	paths, err := filepath.Glob(filepath.Join(f.Dir, "branch-*.json"))
+	if err != nil {
+		return nil, err
+	}
+	var ids []int32
+	for _, path := range paths {
+		idText := strings.TrimSuffix(strings.TrimPrefix(filepath.Base(path), "branch-"), ".json")
+		if id, err := strconv.ParseInt(idText, 10, 32); err == nil {
+			ids = append(ids, int32(id))
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids, nil
+}
+
+func (f *FileRegistry) Resolve(ctx context.Context, id int32) (string, error) {
+	data, err := os.ReadFile(f.path(id))
+	if os.IsNotExist(err) {
+		return "", fmt.Errorf("branch %d is not registered in %s", id, f.Dir)
+	}
+	if err != nil {
+		return "", err
+	}
+	var b ClusterBranch
+	if err := json.Unmarshal(data, &b); err != nil {
+		return "", fmt.Errorf("error reading registration of branch %d: %v", id, err)
+	}
+	if b.Host == "" {
+		b.Host = "localhost"
+	}
+	return b.Address(), nil
+}
+
+func (f *FileRegistry) Watch(ctx context.Context, id int32, update func(address string, err error)) {
+	pollWatch(ctx, f.PollInterval, func() (string, error) { return f.Resolve(ctx, id) }, update)
+}
+
+// pollWatch resolves an address every interval until ctx ends, and calls
+// update with the first result and every one that differs from the last.
+func pollWatch(ctx context.Context, interval time.Duration, resolve func() (string, error), update func(address string, err error)) {
+	address, err := resolve()
+	update(address, err)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+		next, nextErr := resolve()
+		if ctx.Err() != nil {
+			return
+		}
+		if next != address || (nextErr == nil) != (err == nil) {
+			address, err = next, nextErr
+			update(address, err)
+		}
+	}
+}
+
+// NewGRPCResolver adapts a Resolver to gRPC, so that connections dialed to
+// BranchTarget with the grpc.WithResolvers option follow the branch when its
+// address changes.
+func NewGRPCResolver(r Resolver) resolver.Builder {
+	return &grpcResolverBuilder{resolver: r}
+}
+
+type grpcResolverBuilder struct {
+	resolver Resolver
+}
+
+func (b *grpcResolverBuilder) Scheme() string {
+	return ResolverScheme
+}
+
+func (b *grpcResolverBuilder) Build(target resolver.Target, cc resolver.ClientConn, opts resolver.BuildOptions) (resolver.Resolver, error) {
+	id, err := strconv.ParseInt(target.Endpoint(), 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("target %q does not name a branch ID", target.URL.String())
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	go b.resolver.Watch(ctx, int32(id), func(address string, err error) {
+		if err != nil {
+			cc.ReportError(err)
+			return
+		}
+		cc.UpdateState(resolver.State{Addresses: []resolver.Address{{Addr: address}}})
+	})
+	return &grpcResolver{cancel: cancel}, nil
+}
+
+// grpcResolver stops watching the branch when gRPC closes the connection.
+type grpcResolver struct {
+	cancel context.CancelFunc
+}
+
+func (r *grpcResolver) ResolveNow(resolver.ResolveNowOptions) {}
+
+func (r *grpcResolver) Close() {
+	r.cancel()
+}
@@ -0,0 +1,51 @@
+package branch_service
+
+import (
+	"branch_service/branch"
+	"context"
+)
+
+// requires reports whether the session asked for the guarantee. A session
+// that asked for nothing gets read-your-writes, like the original protocol.
+func requires(guarantees []branch.SessionGuarantee, guarantee branch.SessionGuarantee) bool {
+	if len(guarantees) == 0 {
+		return guarantee == branch.SessionGuarantee_READ_YOUR_WRITES
+	}
+	for _, g := range guarantees {
+		if g == guarantee {
+			return true
+		}
+	}
+	return false
+}
+
+// awaitRead blocks until the branch can serve a read under the session's
+// guarantees: read-your-writes needs the session's writes, monotonic reads
+// needs the writes behind its earlier reads. The caller must hold s.mu.
+func (s *BranchServer) awaitRead(ctx context.Context, session *branch.SessionToken, guarantees []branch.SessionGuarantee) error {
+	return s.waitUntil(ctx, func() bool {
+		if requires(guarantees, branch.SessionGuarantee_READ_YOUR_WRITES) && !s.vector.Dominates(session.GetWriteVector()) {
+			return false
+		}
+		if requires(guarantees, branch.SessionGuarantee_MONOTONIC_READS) && !s.vector.Dominates(session.GetReadVector()) {
+			return false
+		}
+		return true
+	})
+}
+
+// awaitWrite blocks until the branch can accept a write under the session's
+// guarantees: monotonic writes needs the session's earlier writes,
+// writes-follow-reads needs the writes behind its earlier reads. The caller
+// must hold s.mu.
+func (s *BranchServer) awaitWrite(ctx context.Context, session *branch.SessionToken, guarantees []branch.SessionGuarantee) error {
+	return s.waitUntil(ctx, func() bool {
+		if requires(guarantees, branch.SessionGuarantee_MONOTONIC_WRITES) && !s.vector.Dominates(session.GetWriteVector()) {
+			return false
+		}
+		if requires(guarantees, branch.SessionGuarantee_WRITES_FOLLOW_READS) && !s.vector.Dominates(session.GetReadVector()) {
+			return false
+		}
+		return true
+	})
+}
@@ -0,0 +1,226 @@
+package branch_service
+
+import (
+	"branch_service/branch"
+	"database/sql"
+
+	"google.golang.org/protobuf/proto"
+	_ "modernc.org/sqlite"
+)
+
+const sqliteSchema = `
+CREATE TABLE IF NOT EXISTS balances (
+	customer_id INTEGER NOT NULL,
+	currency    TEXT NOT NULL,
+	units       INTEGER NOT NULL,
+	PRIMARY KEY (customer_id, currency)
+);
+CREATE TABLE IF NOT EXISTS history (
+	seq         INTEGER PRIMARY KEY AUTOINCREMENT,
+	customer_id INTEGER NOT NULL,
+	event_id    INTEGER NOT NULL,
+	write_event BLOB NOT NULL
+);
+CREATE INDEX IF NOT EXISTS history_event ON history (customer_id, event_id);
+CREATE TABLE IF NOT EXISTS history_base (
+	id   INTEGER PRIMARY KEY CHECK (id = 1),
+	base BLOB NOT NULL
+);
+`
+
+// sqliteStore keeps the branch's state in a SQLite database, with one row
+// per balance. Every change is its own transaction, synced to disk before it
+// returns.
+type sqliteStore struct {
+	db *sql.DB
+}
+
+// OpenSQLiteStore opens the SQLite store in the file at path, creating it if
+// needed.
+func OpenSQLiteStore(path string) (Store, error) {
+	db, err := sql.Open("sqlite", "file:"+path+"?_pragma=journal_mode(WAL)&_pragma=synchronous(FULL)&_pragma=busy_timeout(1000)")
+	if err != nil {
+		return nil, err
+	}
+	// The branch serializes every call, so one connection is enough
+	db.SetMaxOpenConns(1)
+	if _, err := db.Exec(sqliteSchema); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return &sqliteStore{db: db}, nil
+}
+
+func (q *sqliteStore) Account(customerID int32) (*Account, error) {
+	rows, err := q.db.Query(`SELECT currency, units FROM balances WHERE customer_id = ?`, customerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var acc *Account
+	for rows.Next() {
+		var currency string
+		var units int64
+		if err := rows.Scan(&currency, &units); err != nil {
+			return nil, err
+		}
+		if acc == nil {
+			acc = &Account{CustomerID: customerID, Balances: make(map[string]int64)}
+		}
+		acc.Balances[currency] = units
+	}
+	return acc, rows.Err()
+}
+
+func (q *sqliteStore) PutAccount(acc *Account) error {
+	tx, err := q.db.Begin()
+	if err != nil {
+		return err
+	}
+	if err := putSQLiteAccount(tx, acc); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+func putSQLiteAccount(tx *sql.Tx, acc *Account) error {
+	if _, err := tx.Exec(`DELETE FROM balances WHERE customer_id = ?`, acc.CustomerID); err != nil {
+		return err
+	}
+	for currency, units := range acc.Balances {
+		_, err := tx.Exec(`INSERT INTO balances (customer_id, currency, units) VALUES (?, ?, ?)`, acc.CustomerID, currency, units)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (q *sqliteStore) Accounts(fn func(*Account) error) error {
+	rows, err := q.db.Query(`SELECT customer_id, currency, units FROM balances ORDER BY customer_id`)
+	if err != nil {
+		return err
+	}
+	// Read every row before calling fn, which may use the only connection
+	var accounts []*Account
+	for rows.Next() {
+		var customerID int32
+		var currency string
+		var units int64
+		if err := rows.Scan(&customerID, &currency, &units); err != nil {
+			rows.Close()
+			return err
+		}
+		if len(accounts) == 0 || accounts[len(accounts)-1].CustomerID != customerID {
+			accounts = append(accounts, &Account{CustomerID: customerID, Balances: make(map[string]int64)})
+		}
+		accounts[len(accounts)-1].Balances[currency] = units
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	for _, acc := range accounts {
+		if err := fn(acc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (q *sqliteStore) RecordApplied(write *branch.WriteEvent, changed ...*Account) error {
+	data, err := proto.Marshal(write)
+	if err != nil {
+		return err
+	}
+	tx, err := q.db.Begin()
+	if err != nil {
+		return err
+	}
+	for _, acc := range changed {
+		if err := putSQLiteAccount(tx, acc); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	_, err = tx.Exec(`INSERT INTO history (customer_id, event_id, write_event) VALUES (?, ?, ?)`, write.CustomerId, write.WriteEventID, data)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+func (q *sqliteStore) History(fn func(*branch.WriteEvent) error) error {
+	rows, err := q.db.Query(`SELECT write_event FROM history ORDER BY seq`)
+	if err != nil {
+		return err
+	}
+	var writes []*branch.WriteEvent
+	for rows.Next() {
+		var data []byte
+		if err := rows.Scan(&data); err != nil {
+			rows.Close()
+			return err
+		}
+		write := &branch.WriteEvent{}
+		if err := proto.Unmarshal(data, write); err != nil {
+			rows.Close()
+			return err
+		}
+		writes = append(writes, write)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	for _, write := range writes {
+		if err := fn(write); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (q *sqliteStore) CompactHistory(n int, base *branch.HistoryBase) error {
+	data, err := proto.Marshal(base)
+	if err != nil {
+		return err
+	}
+	tx, err := q.db.Begin()
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(`DELETE FROM history WHERE seq IN (SELECT seq FROM history ORDER BY seq LIMIT ?)`, n)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	_, err = tx.Exec(`INSERT OR REPLACE INTO history_base (id, base) VALUES (1, ?)`, data)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+func (q *sqliteStore) HistoryBase() (*branch.HistoryBase, error) {
+	var data []byte
+	err := q.db.QueryRow(`SELECT base FROM history_base WHERE id = 1`).Scan(&data)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	base := &branch.HistoryBase{}
+	if err := proto.Unmarshal(data, base); err != nil {
+		return nil, err
+	}
+	return base, nil
+}
+
+func (q *sqliteStore) Close() error {
+	return q.db.Close()
+}
@@ -0,0 +1,257 @@
+package branch_service
+
+import (
+	"branch_service/branch"
+	"context"
+	"io"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// DefaultStatementPageSize is how many entries GetStatement returns when the
+// request does not say, and MaxStatementPageSize is the most it returns.
+const (
+	DefaultStatementPageSize = 100
+	MaxStatementPageSize     = 1000
+)
+
+// GetStatement streams a page of the customer's statement: the deposits,
+// withdrawals, conversions and transfers applied to the account, in the order
+// this branch applied them, with the balances each left the account with.
+func (s *BranchServer) GetStatement(request *branch.StatementRequest, stream branch.BranchService_GetStatementServer) error {
+	ctx := stream.Context()
+
+	var entries []*branch.StatementEntry
+	var err error
+	if s.raft != nil {
+		entries, err = s.raftStatement(ctx, request, stream)
+	} else {
+		var history *accountHistory
+		s.mu.Lock()
+		// Block until this branch has seen what the customer's session requires
+		err = s.awaitRead(ctx, request.Session, request.Guarantees)
+		if err == nil {
+			history, err = s.accountHistory(request.CustomerId)
+		}
+		s.mu.Unlock()
+		if err == nil {
+			entries, err = history.statement(request)
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		if err := stream.Send(entry); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// statement lists the entries of a page of the customer's statement.
+func (h *accountHistory) statement(request *branch.StatementRequest) ([]*branch.StatementEntry, error) {
+	pageSize := int(request.PageSize)
+	if pageSize <= 0 {
+		pageSize = DefaultStatementPageSize
+	}
+	if pageSize > MaxStatementPageSize {
+		pageSize = MaxStatementPageSize
+	}
+
+	customerID := request.CustomerId
+	var entries []*branch.StatementEntry
+	_, err := h.replay(nil, func(position int64, write *branch.WriteEvent, acc *Account) error {
+		if len(entries) == pageSize {
+			return io.EOF
+		}
+		if position <= request.AfterPosition {
+			return nil
+		}
+		if request.FromTime != 0 && write.Timestamp < request.FromTime {
+			return nil
+		}
+		if request.ToTime != 0 && write.Timestamp >= request.ToTime {
+			return nil
+		}
+
+		entry := &branch.StatementEntry{
+			Position:     position,
+			WriteEventID: write.WriteEventID,
+			OriginBranch: write.OriginBranch,
+			Timestamp:    write.Timestamp,
+			Money:        write.Money,
+			Balance:      acc.balance(write.Money.GetCurrencyCode()),
+		}
+		switch write.Kind {
+		case branch.WriteEvent_DEPOSIT:
+			entry.Kind = branch.StatementEntry_DEPOSIT
+		case branch.WriteEvent_WITHDRAW:
+			entry.Kind = branch.StatementEntry_WITHDRAW
+		case branch.WriteEvent_CONVERT:
+			entry.Kind = branch.StatementEntry_CONVERT
+			entry.Converted = write.Converted
+			entry.ConvertedBalance = acc.balance(write.Converted.GetCurrencyCode())
+		case branch.WriteEvent_TRANSFER:
+			if write.TargetCustomerId == customerID {
+				entry.Kind = branch.StatementEntry_TRANSFER_IN
+				entry.CounterpartyCustomerId = write.CustomerId
+			} else {
+				entry.Kind = branch.StatementEntry_TRANSFER_OUT
+				entry.CounterpartyCustomerId = write.TargetCustomerId
+			}
+		}
+		entries = append(entries, entry)
+		return nil
+	})
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// accountHistory is a copy of a customer's account and of the writes still
+// in the history that moved its money, so that the account can be replayed
+// without holding s.mu.
+type accountHistory struct {
+	acc       *Account             // The account as every write left it
+	currency  string               // The branch's home currency
+	writes    []*branch.WriteEvent // In the order this branch applied them
+	positions []int64              // Of each write among every write the branch has applied
+}
+
+// accountHistory copies a customer's account and the writes that moved its
+// money. The caller must hold s.mu.
+func (s *BranchServer) accountHistory(customerID int32) (*accountHistory, error) {
+	acc, err := s.account(customerID)
+	if err != nil {
+		return nil, err
+	}
+	base, err := s.historyBase()
+	if err != nil {
+		return nil, err
+	}
+	h := &accountHistory{acc: acc, currency: s.currency}
+	position := base.Compacted
+	err = s.store.History(func(write *branch.WriteEvent) error {
+		position++
+		if movesMoneyOf(acc, write) {
+			h.writes = append(h.writes, write)
+			h.positions = append(h.positions, position)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, s.storeFailed(err)
+	}
+	return h, nil
+}
+
+// replay rebuilds the account by replaying its writes in the order this
+// branch applied them, since a branch may apply concurrent writes in another
+// order than their origin did. Replaying starts from the account as the
+// compacted part of the history left it, worked out by undoing every write
+// still in the history. It skips the writes include rejects, if include is
+// set, and calls fn, if set, with each write it replays, its position among
+// every write the branch has applied, and the account the write left.
+// Replaying stops at the first error fn returns. It returns the account as
+// the replayed writes left it.
+func (h *accountHistory) replay(include func(*branch.WriteEvent) bool, fn func(position int64, write *branch.WriteEvent, acc *Account) error) (*Account, error) {
+	acc := h.acc.copy()
+	for _, write := range h.writes {
+		replayMove(acc, write, -1)
+	}
+	// Drop the currencies the account only came to hold in the history
+	for currency, units := range acc.Balances {
+		if units == 0 && currency != h.currency {
+			delete(acc.Balances, currency)
+		}
+	}
+
+	for i, write := range h.writes {
+		if include != nil && !include(write) {
+			continue
+		}
+		replayMove(acc, write, 1)
+		if fn == nil {
+			continue
+		}
+		if err := fn(h.positions[i], write, acc); err != nil {
+			return nil, err
+		}
+	}
+	return acc, nil
+}
+
+// movesMoneyOf reports whether a write moved money in or out of the account.
+// A duplicate moved none.
+func movesMoneyOf(acc *Account, write *branch.WriteEvent) bool {
+	incoming := write.Kind == branch.WriteEvent_TRANSFER && write.TargetCustomerId == acc.CustomerID
+	return (write.CustomerId == acc.CustomerID || incoming) && !write.Duplicate
+}
+
+// replayMove adds the money a write moved in or out of the account, or takes
+// it back out if sign is -1.
+func replayMove(acc *Account, write *branch.WriteEvent, sign int64) {
+	incoming := write.Kind == branch.WriteEvent_TRANSFER && write.TargetCustomerId == acc.CustomerID
+	from := write.Money.GetCurrencyCode()
+	units := sign * write.Money.GetUnits()
+	switch write.Kind {
+	case branch.WriteEvent_DEPOSIT:
+		acc.Balances[from] += units
+	case branch.WriteEvent_WITHDRAW:
+		acc.Balances[from] -= units
+	case branch.WriteEvent_CONVERT:
+		acc.Balances[from] -= units
+		acc.Balances[write.Converted.GetCurrencyCode()] += sign * write.Converted.GetUnits()
+	case branch.WriteEvent_TRANSFER:
+		if incoming {
+			acc.Balances[from] += units
+		} else {
+			acc.Balances[from] -= units
+		}
+	}
+}
+
+// raftStatement reads a page of the customer's statement linearizably at the
+// leader, relaying the leader's stream if this branch is a follower. A
+// follower sends the entries itself and returns none.
+func (s *BranchServer) raftStatement(ctx context.Context, request *branch.StatementRequest, stream branch.BranchService_GetStatementServer) ([]*branch.StatementEntry, error) {
+	s.mu.Lock()
+	leader, err := s.raftLeaderClient(request.ForwardedBy)
+	if err != nil || leader != nil {
+		s.mu.Unlock()
+		if err != nil {
+			return nil, err
+		}
+		forwarded := proto.Clone(request).(*branch.StatementRequest)
+		forwarded.ForwardedBy = s.ID
+		relay, err := leader.GetStatement(ctx, forwarded)
+		if err != nil {
+			return nil, err
+		}
+		for {
+			entry, err := relay.Recv()
+			if err == io.EOF {
+				return nil, nil
+			}
+			if err != nil {
+				return nil, err
+			}
+			if err := stream.Send(entry); err != nil {
+				return nil, err
+			}
+		}
+	}
+	err = s.raftRead(ctx)
+	var history *accountHistory
+	if err == nil {
+		history, err = s.accountHistory(request.CustomerId)
+	}
+	s.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	return history.statement(request)
+}
@@ -0,0 +1,178 @@
+package branch_service
+
+import (
+	"branch_service/branch"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Store holds a branch's accounts and the write events it has applied. The
+// branch serializes every call, so implementations need no locking of their
+// own.
+type Store interface {
+	// Account returns a customer's account, or nil if the store has none.
+	// Changing the account does not change the store.
+	Account(customerID int32) (*Account, error)
+
+	// PutAccount stores an account, replacing the customer's previous one.
+	PutAccount(acc *Account) error
+
+	// Accounts calls fn with every account, ordered by customer ID.
+	Accounts(fn func(*Account) error) error
+
+	// RecordApplied appends a write event to the history of applied writes,
+	// under its customer and event ID, and stores the accounts it changed, as
+	// one atomic step.
+	RecordApplied(write *branch.WriteEvent, changed ...*Account) error
+
+	// History calls fn with every applied write event still in the history,
+	// in the order they were recorded.
+	History(fn func(*branch.WriteEvent) error) error
+
+	// CompactHistory drops the n oldest write events from the history, and
+	// stores base, which sums up every write dropped so far, in one atomic
+	// step.
+	CompactHistory(n int, base *branch.HistoryBase) error
+
+	// HistoryBase returns what CompactHistory last stored, or nil if the
+	// history was never compacted.
+	HistoryBase() (*branch.HistoryBase, error)
+
+	Close() error
+}
+
+// StoreKinds lists the stores OpenStore can open.
+var StoreKinds = []string{"memory", "bolt", "sqlite"}
+
+// OpenStore opens a store of the given kind. The memory store keeps nothing
+// on disk; the others keep their file in dir.
+func OpenStore(kind string, dir string) (Store, error) {
+	switch strings.ToLower(kind) {
+	case "", "memory":
+		return NewMemoryStore(), nil
+	case "bolt":
+		return OpenBoltStore(filepath.Join(dir, "branch.db"))
+	case "sqlite":
+		return OpenSQLiteStore(filepath.Join(dir, "branch.sqlite"))
+	}
+	return nil, fmt.Errorf("unknown store %q, expected one of %s", kind, strings.Join(StoreKinds, ", "))
+}
+
+// SetStore makes the branch keep its state in store instead of memory, and
+// picks up the state the store already holds. A store that keeps its own
+// state on disk makes OpenDataDir unnecessary; Raft mode logs its state in
+// the data directory, so a Raft branch cannot use one. It must be called
+// after SetDedupWindow, and before the branch starts.
+func (s *BranchServer) SetStore(store Store) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.raft != nil {
+		return fmt.Errorf("a Raft branch keeps its state in its data directory")
+	}
+	if s.wal != nil {
+		return fmt.Errorf("the branch already keeps its state in a data directory")
+	}
+
+	// Rebuild the vector clock and the results to answer retries with. The
+	// event IDs of compacted writes are too old to answer retries of
+	s.store = store
+	s.vector = make(VectorClock)
+	s.dedup = newDedupWindow(s.dedup.size)
+	base, err := store.HistoryBase()
+	if err != nil {
+		return err
+	}
+	s.vector.Merge(base.GetVector())
+	for customerID, eventID := range base.GetForgotten() {
+		s.dedup.applied[customerID] = &appliedEvents{forgotten: eventID}
+	}
+	return store.History(func(write *branch.WriteEvent) error {
+		if write.Vector[write.OriginBranch] > s.vector[write.OriginBranch] {
+			s.vector[write.OriginBranch] = write.Vector[write.OriginBranch]
+		}
+		s.remember(write.CustomerId, write.WriteEventID, resultOf(write))
+		return nil
+	})
+}
+
+// storeFailed reports an error of the branch's store to the caller. The
+// caller must hold s.mu.
+func (s *BranchServer) storeFailed(err error) error {
+	return status.Errorf(codes.Internal, "branch %d failed to access its store: %v", s.ID, err)
+}
+
+// memoryStore keeps the branch's state in memory only.
+type memoryStore struct {
+	accounts map[int32]*Account
+	history  []*branch.WriteEvent
+	base     *branch.HistoryBase
+}
+
+// NewMemoryStore returns an empty store that keeps everything in memory.
+func NewMemoryStore() Store {
+	return &memoryStore{accounts: make(map[int32]*Account)}
+}
+
+func (m *memoryStore) Account(customerID int32) (*Account, error) {
+	acc, ok := m.accounts[customerID]
+	if !ok {
+		return nil, nil
+	}
+	return acc.copy(), nil
+}
+
+func (m *memoryStore) PutAccount(acc *Account) error {
+	m.accounts[acc.CustomerID] = acc.copy()
+	return nil
+}
+
+func (m *memoryStore) Accounts(fn func(*Account) error) error {
+	ids := make([]int32, 0, len(m.accounts))
+	for id := range m.accounts {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	for _, id := range ids {
+		if err := fn(m.accounts[id].copy()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *memoryStore) RecordApplied(write *branch.WriteEvent, changed ...*Account) error {
+	for _, acc := range changed {
+		m.accounts[acc.CustomerID] = acc.copy()
+	}
+	m.history = append(m.history, write)
+	return nil
+}
+
+func (m *memoryStore) History(fn func(*branch.WriteEvent) error) error {
+	for _, write := range m.history {
+		if err := fn(write); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *memoryStore) CompactHistory(n int, base *branch.HistoryBase) error {
+	// Copy what is left so that the dropped writes can be freed
+	m.history = append([]*branch.WriteEvent(nil), m.history[n:]...)
+	m.base = base
+	return nil
+}
+
+func (m *memoryStore) HistoryBase() (*branch.HistoryBase, error) {
+	return m.base, nil
+}
+
+func (m *memoryStore) Close() error {
+	return nil
+}
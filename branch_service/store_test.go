@@ -0,0 +1,103 @@
+package branch_service
+
+import (
+	"branch_service/branch"
+	"context"
+	"reflect"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// TestStoresReopen applies writes to a branch with a store on disk, compacts
+// its history, and checks that the branch reopened on the store has the same
+// balances and vector clock, and still answers retries.
+func TestStoresReopen(t *testing.T) {
+	for _, kind := range []string{"bolt", "sqlite"} {
+		t.Run(kind, func(t *testing.T) {
+			dir := t.TempDir()
+			ctx := context.Background()
+			open := func() *BranchServer {
+				s := NewBranchServer(1, &branch.Money{CurrencyCode: "USD", Units: 100}, 0)
+				s.SetDedupWindow(2)
+				store, err := OpenStore(kind, dir)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if err := s.SetStore(store); err != nil {
+					t.Fatal(err)
+				}
+				return s
+			}
+			usdOf := func(units int64) *branch.Money {
+				return &branch.Money{CurrencyCode: "USD", Units: units}
+			}
+
+			s := open()
+			for i := int32(1); i <= 10; i++ {
+				if _, err := s.Deposit(ctx, &branch.DepositRequest{CustomerId: 1 + i%2, WriteEventID: i, Money: usdOf(int64(i))}); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if _, err := s.Withdraw(ctx, &branch.WithdrawRequest{CustomerId: 1, WriteEventID: 11, Money: usdOf(40)}); err != nil {
+				t.Fatal(err)
+			}
+			transfer, err := s.Transfer(ctx, &branch.TransferRequest{CustomerId: 2, TargetCustomerId: 1, WriteEventID: 12, Money: usdOf(5)})
+			if err != nil {
+				t.Fatal(err)
+			}
+			s.mu.Lock()
+			err = s.compactHistory()
+			s.mu.Unlock()
+			if err != nil {
+				t.Fatal(err)
+			}
+			before := make(map[int32]*branch.QueryBalanceResponse)
+			for customerID := int32(1); customerID <= 2; customerID++ {
+				if before[customerID], err = s.QueryBalance(ctx, &branch.QueryBalanceRequest{CustomerId: customerID}); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := s.store.Close(); err != nil {
+				t.Fatal(err)
+			}
+
+			s = open()
+			defer s.store.Close()
+			for customerID, want := range before {
+				response, err := s.QueryBalance(ctx, &branch.QueryBalanceRequest{CustomerId: customerID})
+				if err != nil {
+					t.Fatal(err)
+				}
+				if response.Money.GetUnits() != want.Money.GetUnits() {
+					t.Errorf("customer %d has %d after reopening, want %d", customerID, response.Money.GetUnits(), want.Money.GetUnits())
+				}
+				if !reflect.DeepEqual(response.Vector, want.Vector) {
+					t.Errorf("vector clock is %v after reopening, want %v", response.Vector, want.Vector)
+				}
+			}
+
+			// A retry of a recent write gets its first response
+			retried, err := s.Transfer(ctx, &branch.TransferRequest{CustomerId: 2, TargetCustomerId: 1, WriteEventID: 12, Money: usdOf(5)})
+			if err != nil {
+				t.Fatalf("retrying the transfer: %v", err)
+			}
+			if retried.Balance.GetUnits() != transfer.Balance.GetUnits() {
+				t.Errorf("retried transfer returned balance %d, want %d", retried.Balance.GetUnits(), transfer.Balance.GetUnits())
+			}
+			// A retry of a compacted write is too old to answer
+			_, err = s.Deposit(ctx, &branch.DepositRequest{CustomerId: 1, WriteEventID: 2, Money: usdOf(2)})
+			if status.Code(err) != codes.AlreadyExists {
+				t.Errorf("retrying a compacted deposit: got %v, want %v", err, codes.AlreadyExists)
+			}
+			response, err := s.QueryBalance(ctx, &branch.QueryBalanceRequest{CustomerId: 1})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if response.Money.GetUnits() != before[1].Money.GetUnits() {
+				t.Errorf("retries moved money: customer 1 has %d, want %d", response.Money.GetUnits(), before[1].Money.GetUnits())
+			}
+		})
+	}
+}
@@ -0,0 +1,259 @@
+package branch_service
+
+import (
+	"branch_service/branch"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// CAFile is the certificate of the CA that signs every certificate of a
+	// cluster, in the directory gencerts writes.
+	CAFile = "ca.pem"
+
+	// CustomerIdentity is the name of the certificate customers present.
+	CustomerIdentity = "customer"
+)
+
+// BranchIdentity is the name the certificate of a branch carries, as its
+// common name and DNS name.
+func BranchIdentity(id int32) string {
+	return fmt.Sprintf("branch-%d", id)
+}
+
+// TLSIdentity is a certificate and the CA of the cluster that certificates
+// of other branches and customers are verified against.
+type TLSIdentity struct {
+	Name  string
+	cert  *tls.Certificate // Nil if the holder has no certificate
+	roots *x509.CertPool
+}
+
+// LoadTLSIdentity reads the CA certificate, and the certificate and key of
+// the given name, from a directory gencerts wrote: ca.pem, <name>.pem and
+// <name>-key.pem. If the certificate is optional and missing, the identity
+// only verifies the branches it connects to.
+func LoadTLSIdentity(dir, name string, optional bool) (*TLSIdentity, error) {
+	caPEM, err := os.ReadFile(filepath.Join(dir, CAFile))
+	if err != nil {
+		return nil, fmt.Errorf("error reading CA certificate: %v", err)
+	}
+	roots := x509.NewCertPool()
+	if !roots.AppendCertsFromPEM(caPEM) {
+		return nil, fmt.Errorf("%s holds no certificate", filepath.Join(dir, CAFile))
+	}
+	identity := &TLSIdentity{Name: name, roots: roots}
+
+	certFile := filepath.Join(dir, name+".pem")
+	if _, err := os.Stat(certFile); optional && os.IsNotExist(err) {
+		return identity, nil
+	}
+	cert, err := tls.LoadX509KeyPair(certFile, filepath.Join(dir, name+"-key.pem"))
+	if err != nil {
+		return nil, fmt.Errorf("error reading certificate of %s: %v", name, err)
+	}
+	identity.cert = &cert
+	return identity, nil
+}
+
+// serverCredentials serves TLS with the identity's certificate. Clients
+// may present a certificate signed by the CA, which branches do and
+// customers need not.
+func (t *TLSIdentity) serverCredentials() credentials.TransportCredentials {
+	config := &tls.Config{
+		ClientCAs:  t.roots,
+		ClientAuth: tls.VerifyClientCertIfGiven,
+		MinVersion: tls.VersionTLS12,
+	}
+	if t.cert != nil {
+		config.Certificates = []tls.Certificate{*t.cert}
+	}
+	return credentials.NewTLS(config)
+}
+
+// BranchDialOption is the transport security of a connection to the branch
+// with the given ID, or to any branch if the ID is 0, as when joining through
+// an address. The connection presents the identity's certificate, if it has
+// one, and accepts only a certificate of that branch signed by the CA. A nil
+// identity dials without TLS.
+func BranchDialOption(identity *TLSIdentity, id int32) grpc.DialOption {
+	if identity == nil {
+		return grpc.WithTransportCredentials(insecure.NewCredentials())
+	}
+	config := &tls.Config{
+		// Branches are dialed by ID through a resolver rather than by a host
+		// name, so the certificate is checked by the branch it names instead
+		InsecureSkipVerify: true,
+		VerifyConnection:   identity.verifyBranch(id),
+		MinVersion:         tls.VersionTLS12,
+	}
+	if identity.cert != nil {
+		config.Certificates = []tls.Certificate{*identity.cert}
+	}
+	return grpc.WithTransportCredentials(credentials.NewTLS(config))
+}
+
+// verifyBranch checks that a server's certificate is signed by the CA and
+// names the branch with the given ID, or any branch if the ID is 0.
+func (t *TLSIdentity) verifyBranch(id int32) func(tls.ConnectionState) error {
+	return func(state tls.ConnectionState) error {
+		if len(state.PeerCertificates) == 0 {
+			return errors.New("the branch presented no certificate")
+		}
+		intermediates := x509.NewCertPool()
+		for _, cert := range state.PeerCertificates[1:] {
+			intermediates.AddCert(cert)
+		}
+		leaf := state.PeerCertificates[0]
+		_, err := leaf.Verify(x509.VerifyOptions{
+			Roots:         t.roots,
+			Intermediates: intermediates,
+			KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		})
+		if err != nil {
+			return err
+		}
+		peerID, ok := certBranch(leaf)
+		if !ok {
+			return fmt.Errorf("certificate of %q is not of a branch", leaf.Subject.CommonName)
+		}
+		if id != 0 && peerID != id {
+			return fmt.Errorf("expected branch %d, but the certificate is of branch %d", id, peerID)
+		}
+		return nil
+	}
+}
+
+// certBranch returns the ID of the branch a certificate names.
+func certBranch(cert *x509.Certificate) (int32, bool) {
+	idText, ok := strings.CutPrefix(cert.Subject.CommonName, "branch-")
+	if !ok {
+		return 0, false
+	}
+	id, err := strconv.ParseInt(idText, 10, 32)
+	if err != nil || id <= 0 {
+		return 0, false
+	}
+	return int32(id), true
+}
+
+// callerBranch returns the ID of the branch that made a call, if it
+// presented a verified branch certificate.
+func callerBranch(ctx context.Context) (int32, bool) {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return 0, false
+	}
+	info, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(info.State.VerifiedChains) == 0 {
+		return 0, false
+	}
+	return certBranch(info.State.VerifiedChains[0][0])
+}
+
+// branchOnly lists the methods only branches may call.
+var branchOnly = map[string]bool{
+	"PropagateWithdraw": true,
+	"PropagateDeposit":  true,
+	"PropagateConvert":  true,
+	"PropagateTransfer": true,
+	"SyncWrites":        true,
+	"JoinCluster":       true,
+	"LeaveCluster":      true,
+	"Ping":              true,
+	"PingReq":           true,
+	"RequestVote":       true,
+	"AppendEntries":     true,
+}
+
+// claimedBranch returns the branch a request says it comes from, or 0 if
+// it names none, as a customer's request does.
+func (s *BranchServer) claimedBranch(request interface{}) int32 {
+	switch r := request.(type) {
+	case interface{ GetOriginBranch() int32 }:
+		return r.GetOriginBranch()
+	case interface{ GetForwardedBy() int32 }:
+		return r.GetForwardedBy()
+	case interface{ GetFrom() int32 }:
+		return r.GetFrom()
+	case *branch.SyncWritesRequest:
+		return r.BranchId
+	case *branch.JoinClusterRequest:
+		return r.Member.GetId()
+	case *branch.LeaveClusterRequest:
+		if r.BranchId == 0 {
+			// A branch is asked to leave by the holder of its own certificate
+			return s.ID
+		}
+		return r.BranchId
+	case *branch.RequestVoteRequest:
+		return r.CandidateId
+	case *branch.AppendEntriesRequest:
+		return r.LeaderId
+	}
+	return 0
+}
+
+// authorize lets only branches call the methods between branches, and
+// only as themselves, so that a customer or another branch cannot make up
+// writes of a branch. Customers may call the other methods without a
+// certificate.
+func (s *BranchServer) authorize(ctx context.Context, request interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if err := s.checkCaller(ctx, info.FullMethod, request); err != nil {
+		return nil, err
+	}
+	return handler(ctx, request)
+}
+
+// authorizeStream checks the requests of a streaming call the way authorize
+// checks those of other calls, as each one arrives.
+func (s *BranchServer) authorizeStream(server interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	return handler(server, &authorizedStream{ServerStream: stream, s: s, method: info.FullMethod})
+}
+
+// authorizedStream is a stream whose requests are checked by authorize.
+type authorizedStream struct {
+	grpc.ServerStream
+	s      *BranchServer
+	method string
+}
+
+func (a *authorizedStream) RecvMsg(request interface{}) error {
+	if err := a.ServerStream.RecvMsg(request); err != nil {
+		return err
+	}
+	return a.s.checkCaller(a.Context(), a.method, request)
+}
+
+// checkCaller fails a request to a method between branches that does not
+// come from a branch, and a request that claims to come from a branch other
+// than the caller.
+func (s *BranchServer) checkCaller(ctx context.Context, fullMethod string, request interface{}) error {
+	method := fullMethod[strings.LastIndex(fullMethod, "/")+1:]
+	claimed := s.claimedBranch(request)
+	if !branchOnly[method] && claimed == 0 {
+		return nil
+	}
+	caller, ok := callerBranch(ctx)
+	if !ok {
+		return status.Errorf(codes.PermissionDenied, "only branches may call %s", method)
+	}
+	if claimed != 0 && claimed != caller {
+		return status.Errorf(codes.PermissionDenied, "branch %d may not call %s as branch %d", caller, method, claimed)
+	}
+	return nil
+}
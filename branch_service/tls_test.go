@@ -0,0 +1,184 @@
+package branch_service
+
+import (
+	"branch_service/branch"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// writeTestCerts writes a CA, and certificates signed by it, to dir, laid
+// out like gencerts does. holders maps the name of each certificate to
+// whether it is a branch's, which branches also serve with.
+func writeTestCerts(t *testing.T, dir string, holders map[string]bool) {
+	t.Helper()
+	write := func(name string, der []byte, key *ecdsa.PrivateKey) {
+		keyDER, err := x509.MarshalECPrivateKey(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+		keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+		if err := os.WriteFile(filepath.Join(dir, name+".pem"), certPEM, 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, name+"-key.pem"), keyPEM, 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	sign := func(template, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) (*x509.Certificate, *ecdsa.PrivateKey) {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if parent == nil {
+			parent, parentKey = template, key
+		}
+		template.SerialNumber = big.NewInt(time.Now().UnixNano())
+		template.NotBefore = time.Now().Add(-time.Hour)
+		template.NotAfter = time.Now().Add(time.Hour)
+		der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		cert, err := x509.ParseCertificate(der)
+		if err != nil {
+			t.Fatal(err)
+		}
+		write(template.Subject.CommonName, der, key)
+		return cert, key
+	}
+
+	ca, caKey := sign(&x509.Certificate{
+		Subject:               pkix.Name{CommonName: "ca"},
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}, nil, nil)
+	for name, server := range holders {
+		template := &x509.Certificate{
+			Subject:     pkix.Name{CommonName: name},
+			KeyUsage:    x509.KeyUsageDigitalSignature,
+			ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+			DNSNames:    []string{name},
+		}
+		if server {
+			template.ExtKeyUsage = append(template.ExtKeyUsage, x509.ExtKeyUsageServerAuth)
+			template.IPAddresses = []net.IP{net.ParseIP("127.0.0.1")}
+		}
+		sign(template, ca, caKey)
+	}
+}
+
+// TestMutualTLS checks that only branches may call the methods between
+// branches, and only as themselves, and that a client only accepts the
+// branch it dialed.
+func TestMutualTLS(t *testing.T) {
+	dir := t.TempDir()
+	writeTestCerts(t, dir, map[string]bool{
+		BranchIdentity(1): true,
+		BranchIdentity(2): true,
+		CustomerIdentity:  false,
+	})
+	s := NewBranchServer(1, &branch.Money{CurrencyCode: "USD", Units: 100}, 0)
+	var err error
+	if s.TLS, err = LoadTLSIdentity(dir, BranchIdentity(1), false); err != nil {
+		t.Fatal(err)
+	}
+	addr, err := s.StartBranchServer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { s.Stop(context.Background()) })
+
+	// dial connects to branch 1 as the holder of a certificate, expecting
+	// the branch with the given ID to answer
+	dial := func(holder string, id int32) branch.BranchServiceClient {
+		identity, err := LoadTLSIdentity(dir, holder, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		conn, err := grpc.Dial(addr.String(), BranchDialOption(identity, id))
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { conn.Close() })
+		return branch.NewBranchServiceClient(conn)
+	}
+	customer := dial(CustomerIdentity, 1)
+	branch2 := dial(BranchIdentity(2), 1)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	money := &branch.Money{CurrencyCode: "USD", Units: 1}
+	for _, c := range []struct {
+		name string
+		call func() error
+		want codes.Code
+	}{
+		{"a customer propagating a deposit", func() error {
+			_, err := customer.PropagateDeposit(ctx, &branch.PropagateDepositRequest{CustomerId: 1, WriteEventID: 1, OriginBranch: 2, Money: money})
+			return err
+		}, codes.PermissionDenied},
+		{"branch 2 propagating a deposit of branch 3", func() error {
+			_, err := branch2.PropagateDeposit(ctx, &branch.PropagateDepositRequest{CustomerId: 1, WriteEventID: 1, OriginBranch: 3, Money: money})
+			return err
+		}, codes.PermissionDenied},
+		{"branch 2 propagating its own deposit", func() error {
+			_, err := branch2.PropagateDeposit(ctx, &branch.PropagateDepositRequest{CustomerId: 1, WriteEventID: 1, OriginBranch: 2, Vector: map[int32]int64{2: 1}, Money: money})
+			return err
+		}, codes.OK},
+		{"a customer depositing", func() error {
+			_, err := customer.Deposit(ctx, &branch.DepositRequest{CustomerId: 1, WriteEventID: 2, Money: money})
+			return err
+		}, codes.OK},
+		{"a customer reading a statement", func() error {
+			return readStatement(ctx, customer, &branch.StatementRequest{CustomerId: 1})
+		}, codes.OK},
+		{"a customer reading a statement as forwarded by branch 2", func() error {
+			return readStatement(ctx, customer, &branch.StatementRequest{CustomerId: 1, ForwardedBy: 2})
+		}, codes.PermissionDenied},
+	} {
+		if err := c.call(); status.Code(err) != c.want {
+			t.Errorf("%s: got %v, want %v", c.name, err, c.want)
+		}
+	}
+
+	// Branch 1 presents its own certificate, which a client expecting
+	// branch 2 rejects
+	wrong := dial(CustomerIdentity, 2)
+	if _, err := wrong.QueryBalance(ctx, &branch.QueryBalanceRequest{CustomerId: 1}); status.Code(err) != codes.Unavailable {
+		t.Errorf("dialing branch 2 reached branch 1: got %v, want %v", err, codes.Unavailable)
+	}
+}
+
+// readStatement reads a whole statement, and returns the error that ended
+// it early, if any.
+func readStatement(ctx context.Context, client branch.BranchServiceClient, request *branch.StatementRequest) error {
+	stream, err := client.GetStatement(ctx, request)
+	if err != nil {
+		return err
+	}
+	for {
+		if _, err := stream.Recv(); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package branch_service
+
+import (
+	"branch_service/branch"
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Transfer moves money from one customer's account to another's. The debit
+// and the credit are a single write event, so every branch applies both or
+// neither, and there is nothing to compensate if a branch fails midway.
+func (s *BranchServer) Transfer(ctx context.Context, request *branch.TransferRequest) (*branch.TransferResponse, error) {
+
+	money, err := s.amount(request.Money)
+	if err != nil {
+		return nil, err
+	}
+	if request.TargetCustomerId == request.CustomerId {
+		return nil, status.Errorf(codes.InvalidArgument, "cannot transfer from customer %d's account to itself", request.CustomerId)
+	}
+
+	if s.raft != nil {
+		return s.raftTransfer(ctx, request, money)
+	}
+
+	// A transfer takes money out of the sending account like a withdrawal,
+	// so that account's primary branch decides if withdrawals are
+	// coordinated. Crediting the receiving account needs no check.
+	if response, forwarded, err := s.forwardTransfer(ctx, request); forwarded {
+		return response, err
+	}
+
+	s.mu.Lock()
+	if err := s.checkMember(); err != nil {
+		s.mu.Unlock()
+		return nil, err
+	}
+
+	// Block until this branch has seen what the customer's session requires
+	if err := s.awaitWrite(ctx, request.Session, request.Guarantees); err != nil {
+		s.mu.Unlock()
+		return nil, err
+	}
+
+	// A retried transfer gets the response it got the first time
+	if result, ok := s.replayed(request.CustomerId, request.WriteEventID); ok {
+		s.mu.Unlock()
+		if result.Forgotten {
+			return nil, errForgotten(request.CustomerId, request.WriteEventID)
+		}
+		return &branch.TransferResponse{
+			Balance:         result.Balance,
+			Vector:          result.Vector,
+			PropagationMode: s.PropagationMode,
+		}, nil
+	}
+
+	// Check if there's enough balance to send, and move the money
+	write := &branch.WriteEvent{
+		Kind:             branch.WriteEvent_TRANSFER,
+		CustomerId:       request.CustomerId,
+		WriteEventID:     request.WriteEventID,
+		Money:            money,
+		TargetCustomerId: request.TargetCustomerId,
+		OriginBranch:     s.ID,
+	}
+	if err := s.settle(write); err != nil {
+		s.mu.Unlock()
+		return nil, err
+	}
+	s.stamp(write)
+	if err := s.applyWrite(write); err != nil {
+		s.mu.Unlock()
+		return nil, err
+	}
+	done, peers := s.enqueue(write)
+	s.mu.Unlock()
+
+	acked := s.awaitDelivery(write, done, peers)
+	return &branch.TransferResponse{
+		Balance:         write.Balance,
+		Vector:          write.Vector,
+		PropagationMode: s.PropagationMode,
+		PeersAcked:      acked,
+	}, nil
+}
+
+// PropagateTransfer applies a transfer made at a peer branch to both
+// customers' accounts.
+func (s *BranchServer) PropagateTransfer(ctx context.Context, request *branch.PropagateTransferRequest) (*branch.PropagateTransferResponse, error) {
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Wait for the writes it depends on, and skip it if it was already applied
+	err := s.applyRemote(ctx, &branch.WriteEvent{
+		Kind:             branch.WriteEvent_TRANSFER,
+		CustomerId:       request.CustomerId,
+		WriteEventID:     request.WriteEventID,
+		Money:            request.Money,
+		TargetCustomerId: request.TargetCustomerId,
+		OriginBranch:     request.OriginBranch,
+		Vector:           request.Vector,
+		Balance:          request.Balance,
+		Timestamp:        request.Timestamp,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &branch.PropagateTransferResponse{
+		Success: true,
+	}, nil
+}
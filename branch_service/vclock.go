@@ -0,0 +1,78 @@
+package branch_service
+
+import (
+	"branch_service/branch"
+	"context"
+	"time"
+)
+
+// VectorClock maps a branch ID to the number of write events originating at
+// that branch that are covered. Branches apply the writes of each origin in
+// order, so a count n stands for that origin's writes 1 through n.
+type VectorClock map[int32]int64
+
+// Dominates reports whether v covers every write event covered by other.
+func (v VectorClock) Dominates(other map[int32]int64) bool {
+	for branchID, count := range other {
+		if v[branchID] < count {
+			return false
+		}
+	}
+	return true
+}
+
+// Merge raises every entry of v to at least the matching entry of other.
+func (v VectorClock) Merge(other map[int32]int64) {
+	for branchID, count := range other {
+		if v[branchID] < count {
+			v[branchID] = count
+		}
+	}
+}
+
+// Copy returns a copy of v that can be handed out without holding the lock
+// protecting v.
+func (v VectorClock) Copy() map[int32]int64 {
+	c := make(map[int32]int64, len(v))
+	for branchID, count := range v {
+		c[branchID] = count
+	}
+	return c
+}
+
+// stamp stamps a new write event originating at this branch with its vector
+// clock, everything applied here so far plus the write itself, and the time
+// the branch accepted it. The caller must hold s.mu.
+func (s *BranchServer) stamp(write *branch.WriteEvent) {
+	write.Vector = s.vector.Copy()
+	write.Vector[s.ID]++
+	write.Timestamp = time.Now().UnixMilli()
+}
+
+// readyToApply reports whether every write event the stamped write depends on
+// has been applied here and the write is the next one from its origin. The
+// caller must hold s.mu.
+func (s *BranchServer) readyToApply(origin int32, vector map[int32]int64) bool {
+	if s.vector[origin] != vector[origin]-1 {
+		return false
+	}
+	for branchID, count := range vector {
+		if branchID != origin && s.vector[branchID] < count {
+			return false
+		}
+	}
+	return true
+}
+
+// awaitCausal blocks until the write stamped with vector at origin can be
+// applied in causal order. It reports false if the write has already been
+// applied here. The caller must hold s.mu.
+func (s *BranchServer) awaitCausal(ctx context.Context, origin int32, vector map[int32]int64) (bool, error) {
+	err := s.waitUntil(ctx, func() bool {
+		return s.vector[origin] >= vector[origin] || s.readyToApply(origin, vector)
+	})
+	if err != nil {
+		return false, err
+	}
+	return s.vector[origin] < vector[origin], nil
+}
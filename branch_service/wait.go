@@ -0,0 +1,52 @@
+package branch_service
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// DefaultMaxQueryWait is how long a request may block waiting for a write
+// event to reach this branch unless the branch is configured otherwise.
+const DefaultMaxQueryWait = 30 * time.Second
+
+// notifyApplied wakes every request waiting for a write event to be applied.
+// The caller must hold s.mu.
+func (s *BranchServer) notifyApplied() {
+	close(s.applied)
+	s.applied = make(chan struct{})
+}
+
+// waitUntil blocks until ready returns true, re-checking it each time a write
+// event is applied. It gives up with a gRPC status error when the caller's
+// context ends, the branch's maximum wait has passed or the branch is
+// stopping. The caller must hold s.mu; it is released while waiting and held
+// again on return.
+func (s *BranchServer) waitUntil(ctx context.Context, ready func() bool) error {
+	if ready() {
+		return nil
+	}
+
+	timer := time.NewTimer(s.MaxQueryWait)
+	defer timer.Stop()
+	for !ready() {
+		if s.stopping {
+			return status.Errorf(codes.Unavailable, "branch %d is shutting down", s.ID)
+		}
+		applied := s.applied
+		s.mu.Unlock()
+		select {
+		case <-applied:
+			s.mu.Lock()
+		case <-ctx.Done():
+			s.mu.Lock()
+			return status.FromContextError(ctx.Err()).Err()
+		case <-timer.C:
+			s.mu.Lock()
+			return status.Errorf(codes.DeadlineExceeded, "write event not applied at branch %d within %v", s.ID, s.MaxQueryWait)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,189 @@
+package branch_service
+
+import (
+	"branch_service/branch"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// walHeaderSize is the length and CRC-32C checksum written before each record.
+const walHeaderSize = 8
+
+var walTable = crc32.MakeTable(crc32.Castagnoli)
+
+// wal is a branch's write-ahead log. It is a sequence of numbered files, each
+// holding records framed with their length and checksum. A record is synced
+// to disk before the change it describes takes effect.
+type wal struct {
+	dir     string
+	seq     int64 // Sequence number of the file being appended to
+	file    *os.File
+	size    int64
+	records int // Records appended since the file was opened
+}
+
+func walName(seq int64) string {
+	return fmt.Sprintf("wal-%08d.log", seq)
+}
+
+// openWAL opens log file seq in dir for appending, creating it if needed.
+func openWAL(dir string, seq int64) (*wal, error) {
+	file, err := os.OpenFile(filepath.Join(dir, walName(seq)), os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, err
+	}
+	size, err := file.Seek(0, io.SeekEnd)
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	if err := syncDir(dir); err != nil {
+		file.Close()
+		return nil, err
+	}
+	return &wal{dir: dir, seq: seq, file: file, size: size}, nil
+}
+
+// append writes a record and syncs it to disk. A record that could not be
+// written and synced completely is cut off again, so the log stays readable
+// and never replays a write that failed.
+func (w *wal) append(record *branch.WalRecord) error {
+	data, err := proto.Marshal(record)
+	if err != nil {
+		return err
+	}
+	frame := make([]byte, walHeaderSize+len(data))
+	binary.LittleEndian.PutUint32(frame[0:4], uint32(len(data)))
+	binary.LittleEndian.PutUint32(frame[4:8], crc32.Checksum(data, walTable))
+	copy(frame[walHeaderSize:], data)
+
+	if _, err := w.file.Write(frame); err != nil {
+		w.cut()
+		return err
+	}
+	// A record that was not synced may or may not be on disk, and the write
+	// it describes fails, so it must not be replayed either
+	if err := w.file.Sync(); err != nil {
+		w.cut()
+		return err
+	}
+	w.size += int64(len(frame))
+	w.records++
+	return nil
+}
+
+// cut removes whatever follows the last complete record from the file.
+func (w *wal) cut() {
+	w.file.Truncate(w.size)
+	w.file.Seek(w.size, io.SeekStart)
+}
+
+// rotate starts the next log file, so that the files before it can be
+// removed once a snapshot covers them.
+func (w *wal) rotate() error {
+	next, err := openWAL(w.dir, w.seq+1)
+	if err != nil {
+		return err
+	}
+	w.file.Close()
+	*w = *next
+	return nil
+}
+
+func (w *wal) close() error {
+	return w.file.Close()
+}
+
+// readWAL reads the records of one log file. A record cut short by a crash
+// ends the file; if tail is set, the file is truncated there so that new
+// records can follow, and otherwise it is reported as corruption.
+func readWAL(path string, tail bool) ([]*branch.WalRecord, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var records []*branch.WalRecord
+	offset := 0
+	for offset < len(data) {
+		if len(data)-offset < walHeaderSize {
+			break
+		}
+		length := int(binary.LittleEndian.Uint32(data[offset : offset+4]))
+		checksum := binary.LittleEndian.Uint32(data[offset+4 : offset+8])
+		end := offset + walHeaderSize + length
+		if end > len(data) || crc32.Checksum(data[offset+walHeaderSize:end], walTable) != checksum {
+			break
+		}
+		record := &branch.WalRecord{}
+		if err := proto.Unmarshal(data[offset+walHeaderSize:end], record); err != nil {
+			return nil, fmt.Errorf("%s: record at offset %d: %v", path, offset, err)
+		}
+		records = append(records, record)
+		offset = end
+	}
+	if offset < len(data) {
+		if !tail {
+			return nil, fmt.Errorf("%s: corrupt record at offset %d", path, offset)
+		}
+		if err := os.Truncate(path, int64(offset)); err != nil {
+			return nil, err
+		}
+	}
+	return records, nil
+}
+
+// walSequences lists the sequence numbers of the log files in dir, in order.
+func walSequences(dir string) ([]int64, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var seqs []int64
+	for _, entry := range entries {
+		var seq int64
+		name := entry.Name()
+		if !strings.HasPrefix(name, "wal-") {
+			continue
+		}
+		if _, err := fmt.Sscanf(name, "wal-%d.log", &seq); err == nil && name == walName(seq) {
+			seqs = append(seqs, seq)
+		}
+	}
+	sort.Slice(seqs, func(i, j int) bool { return seqs[i] < seqs[j] })
+	return seqs, nil
+}
+
+// removeWALsBefore deletes the log files a snapshot has made obsolete.
+func removeWALsBefore(dir string, seq int64) error {
+	seqs, err := walSequences(dir)
+	if err != nil {
+		return err
+	}
+	for _, old := range seqs {
+		if old < seq {
+			if err := os.Remove(filepath.Join(dir, walName(old))); err != nil && !errors.Is(err, os.ErrNotExist) {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// syncDir makes the creation, renaming or removal of files in dir durable.
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+	return d.Sync()
+}
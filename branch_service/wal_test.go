@@ -0,0 +1,123 @@
+package branch_service
+
+import (
+	"branch_service/branch"
+	"context"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// crashCopy copies a running branch's data directory, as a crash would leave
+// it on disk, and returns the copy.
+func crashCopy(t *testing.T, dir string) string {
+	t.Helper()
+	crashed := t.TempDir()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, entry := range entries {
+		data, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(crashed, entry.Name()), data, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return crashed
+}
+
+// startDurableBranch starts branch 1 with its state in dir.
+func startDurableBranch(t *testing.T, dir string) branch.BranchServiceClient {
+	t.Helper()
+	_, clients := startTestBranches(t, 1, 100, func(s *BranchServer) {
+		if err := s.OpenDataDir(dir); err != nil {
+			t.Fatalf("opening the data directory: %v", err)
+		}
+	})
+	return clients[0]
+}
+
+// TestWALReplay writes to a branch with a data directory, crashes it while
+// it is writing a record, and checks that the branch restarts with every
+// write it acknowledged, and still recognises their retries.
+func TestWALReplay(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	client := startDurableBranch(t, dir)
+	usdOf := func(units int64) *branch.Money {
+		return &branch.Money{CurrencyCode: "USD", Units: units}
+	}
+
+	for i := int32(1); i <= 10; i++ {
+		if _, err := client.Deposit(ctx, &branch.DepositRequest{CustomerId: 1 + i%2, WriteEventID: i, Money: usdOf(int64(i))}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := client.Withdraw(ctx, &branch.WithdrawRequest{CustomerId: 1, WriteEventID: 11, Money: usdOf(40)}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Transfer(ctx, &branch.TransferRequest{CustomerId: 2, TargetCustomerId: 1, WriteEventID: 12, Money: usdOf(5)}); err != nil {
+		t.Fatal(err)
+	}
+	before := make(map[int32]*branch.QueryBalanceResponse)
+	for customerID := int32(1); customerID <= 2; customerID++ {
+		response, err := client.QueryBalance(ctx, &branch.QueryBalanceRequest{CustomerId: customerID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		before[customerID] = response
+	}
+
+	// The branch dies halfway through writing the next record
+	crashed := crashCopy(t, dir)
+	seqs, err := walSequences(crashed)
+	if err != nil || len(seqs) == 0 {
+		t.Fatalf("no write-ahead log in %v: %v", seqs, err)
+	}
+	torn := make([]byte, walHeaderSize+3)
+	binary.LittleEndian.PutUint32(torn, 100)
+	file, err := os.OpenFile(filepath.Join(crashed, walName(seqs[len(seqs)-1])), os.O_APPEND|os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	file.Write(torn)
+	file.Close()
+
+	client = startDurableBranch(t, crashed)
+	for customerID, want := range before {
+		response, err := client.QueryBalance(ctx, &branch.QueryBalanceRequest{CustomerId: customerID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if response.Money.GetUnits() != want.Money.GetUnits() {
+			t.Errorf("customer %d has %d after the crash, want %d", customerID, response.Money.GetUnits(), want.Money.GetUnits())
+		}
+		if !reflect.DeepEqual(response.Vector, want.Vector) {
+			t.Errorf("vector clock is %v after the crash, want %v", response.Vector, want.Vector)
+		}
+	}
+
+	// A retry gets the first response, without moving money again
+	response, err := client.Deposit(ctx, &branch.DepositRequest{CustomerId: 1, WriteEventID: 10, Money: usdOf(10)})
+	if err != nil {
+		t.Fatalf("retrying a deposit made before the crash: %v", err)
+	}
+	if units := response.Balance.GetUnits(); units != 100+2+4+6+8+10 {
+		t.Errorf("retried deposit returned balance %d", units)
+	}
+
+	// Records written after the torn one are replayed on the next restart
+	if _, err := client.Deposit(ctx, &branch.DepositRequest{CustomerId: 1, WriteEventID: 13, Money: usdOf(7)}); err != nil {
+		t.Fatal(err)
+	}
+	client = startDurableBranch(t, crashCopy(t, crashed))
+	awaitBalances(t, []branch.BranchServiceClient{client}, map[int32]int64{
+		1: before[1].Money.GetUnits() + 7,
+		2: before[2].Money.GetUnits(),
+	}, 0)
+}
@@ -0,0 +1,124 @@
+package branch_service
+
+import (
+	"branch_service/branch"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// WithdrawalMode is how branches decide whether an account can cover a
+// withdrawal.
+type WithdrawalMode int
+
+const (
+	// LocalWithdrawals checks the balance at the branch the customer is
+	// talking to. Concurrent withdrawals at different branches can together
+	// overdraw the account.
+	LocalWithdrawals WithdrawalMode = iota
+
+	// PrimaryWithdrawals sends every withdrawal from an account to the
+	// account's primary branch. The primary has applied every withdrawal
+	// and some of the deposits, so its balance never exceeds the true one,
+	// and a withdrawal it accepts can never take the account below zero.
+	PrimaryWithdrawals
+)
+
+// ParseWithdrawalMode parses "local" or "primary".
+func ParseWithdrawalMode(name string) (WithdrawalMode, error) {
+	switch strings.ToLower(name) {
+	case "local":
+		return LocalWithdrawals, nil
+	case "primary":
+		return PrimaryWithdrawals, nil
+	}
+	return 0, fmt.Errorf("unknown withdrawal mode %q", name)
+}
+
+// primaryFor picks the primary branch of a customer's account. Every branch
+// that knows the same peers picks the same one. The caller must hold s.mu.
+func (s *BranchServer) primaryFor(customerID int32) int32 {
+	ids := []int32{s.ID}
+	for peerID := range s.peers {
+		ids = append(ids, peerID)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	index := int(customerID) % len(ids)
+	if index < 0 {
+		index += len(ids)
+	}
+	return ids[index]
+}
+
+// primaryClient returns the client of the account's primary branch when
+// withdrawals are coordinated and this branch is not the primary, or nil if
+// the request should be handled here.
+func (s *BranchServer) primaryClient(customerID int32, forwardedBy int32) (branch.BranchServiceClient, error) {
+	if s.WithdrawalMode != PrimaryWithdrawals {
+		return nil, nil
+	}
+
+	s.mu.Lock()
+	primary := s.primaryFor(customerID)
+	client := s.peers[primary]
+	s.mu.Unlock()
+
+	if primary == s.ID {
+		return nil, nil
+	}
+	if forwardedBy != 0 {
+		// The forwarding branch and this one disagree on who the primary
+		// is; don't bounce the request around
+		return nil, status.Errorf(codes.FailedPrecondition, "branch %d is not the primary of customer %d's account, branch %d is", s.ID, customerID, primary)
+	}
+	return client, nil
+}
+
+// forwardWithdrawal sends a withdrawal to the account's primary branch when
+// withdrawals are coordinated and this branch is not the primary. It reports
+// false if the withdrawal should be handled here.
+func (s *BranchServer) forwardWithdrawal(ctx context.Context, request *branch.WithdrawRequest) (*branch.WithdrawResponse, bool, error) {
+	client, err := s.primaryClient(request.CustomerId, request.ForwardedBy)
+	if err != nil || client == nil {
+		return nil, err != nil, err
+	}
+
+	forwarded := proto.Clone(request).(*branch.WithdrawRequest)
+	forwarded.ForwardedBy = s.ID
+	response, err := client.Withdraw(ctx, forwarded)
+	return response, true, err
+}
+
+// forwardConversion sends a currency conversion to the account's primary
+// branch like a withdrawal, since it takes money out of the account too.
+func (s *BranchServer) forwardConversion(ctx context.Context, request *branch.ConvertCurrencyRequest) (*branch.ConvertCurrencyResponse, bool, error) {
+	client, err := s.primaryClient(request.CustomerId, request.ForwardedBy)
+	if err != nil || client == nil {
+		return nil, err != nil, err
+	}
+
+	forwarded := proto.Clone(request).(*branch.ConvertCurrencyRequest)
+	forwarded.ForwardedBy = s.ID
+	response, err := client.ConvertCurrency(ctx, forwarded)
+	return response, true, err
+}
+
+// forwardTransfer sends a transfer to the primary branch of the sending
+// account like a withdrawal, since it takes money out of that account.
+func (s *BranchServer) forwardTransfer(ctx context.Context, request *branch.TransferRequest) (*branch.TransferResponse, bool, error) {
+	client, err := s.primaryClient(request.CustomerId, request.ForwardedBy)
+	if err != nil || client == nil {
+		return nil, err != nil, err
+	}
+
+	forwarded := proto.Clone(request).(*branch.TransferRequest)
+	forwarded.ForwardedBy = s.ID
+	response, err := client.Transfer(ctx, forwarded)
+	return response, true, err
+}
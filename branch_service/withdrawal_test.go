@@ -0,0 +1,104 @@
+package branch_service
+
+import (
+	"branch_service/branch"
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestPrimaryWithdrawals withdraws from the same accounts at every branch at
+// once, far more than the accounts hold, and checks that with withdrawals
+// going to primary branches no replica of any account ends below zero.
+func TestPrimaryWithdrawals(t *testing.T) {
+	const (
+		branches  = 4
+		customers = 4
+		opening   = 100
+		workers   = 3 // Per branch and customer
+		amount    = 30
+	)
+	_, clients := startTestCluster(t, branches, opening, func(s *BranchServer) {
+		s.WithdrawalMode = PrimaryWithdrawals
+	})
+	ctx := context.Background()
+
+	var withdrawn [customers + 1]atomic.Int64
+	var eventIDs [customers + 1]atomic.Int32
+	start := make(chan struct{})
+	var wg sync.WaitGroup
+	for _, client := range clients {
+		for customerID := int32(1); customerID <= customers; customerID++ {
+			for w := 0; w < workers; w++ {
+				wg.Add(1)
+				go func(client branch.BranchServiceClient, customerID int32) {
+					defer wg.Done()
+					<-start
+					response, err := client.Withdraw(ctx, &branch.WithdrawRequest{
+						CustomerId:   customerID,
+						WriteEventID: eventIDs[customerID].Add(1),
+						Money:        &branch.Money{CurrencyCode: "USD", Units: amount},
+					})
+					if err != nil {
+						// The account could not cover it
+						return
+					}
+					if response.Balance.GetUnits() < 0 {
+						t.Errorf("withdrawal left customer %d with %d", customerID, response.Balance.GetUnits())
+					}
+					withdrawn[customerID].Add(amount)
+				}(client, customerID)
+			}
+		}
+	}
+	close(start)
+	wg.Wait()
+
+	want := make(map[int32]int64)
+	for customerID := int32(1); customerID <= customers; customerID++ {
+		want[customerID] = opening - withdrawn[customerID].Load()
+		if want[customerID] < 0 {
+			t.Fatalf("customer %d withdrew %d from %d", customerID, withdrawn[customerID].Load(), opening)
+		}
+	}
+	awaitBalances(t, clients, want, 30*time.Second)
+}
+
+// TestLocalWithdrawalsOverdraw shows the overdraft primary branches prevent:
+// checked only against their own replica, withdrawals made at different
+// branches before they hear of each other all succeed, and together take the
+// account below zero once they have propagated.
+func TestLocalWithdrawalsOverdraw(t *testing.T) {
+	const (
+		branches = 4
+		opening  = 100
+		amount   = 60
+	)
+	servers, clients := startTestBranches(t, branches, opening, func(s *BranchServer) {
+		s.AntiEntropyInterval = 50 * time.Millisecond
+	})
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	for i, client := range clients {
+		wg.Add(1)
+		go func(client branch.BranchServiceClient, eventID int32) {
+			defer wg.Done()
+			_, err := client.Withdraw(ctx, &branch.WithdrawRequest{
+				CustomerId:   1,
+				WriteEventID: eventID,
+				Money:        &branch.Money{CurrencyCode: "USD", Units: amount},
+			})
+			if err != nil {
+				t.Errorf("withdrawal %d: %v", eventID, err)
+			}
+		}(client, int32(i+1))
+	}
+	wg.Wait()
+
+	// Anti-entropy brings every withdrawal to every branch
+	connectTestBranches(servers, clients)
+	awaitBalances(t, clients, map[int32]int64{1: opening - branches*amount}, 30*time.Second)
+}
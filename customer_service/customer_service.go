@@ -1,34 +1,73 @@
 package main
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"flag"
 	"fmt"
+	"io"
 	"log"
 	"os"
+	"strings"
+	"time"
 
+	"branch_service"
 	"branch_service/branch"
 
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
+// maxAttempts is how many times a request is sent before giving up on a
+// branch that keeps failing with a transient error.
+const maxAttempts = 3
+
 type Customer struct {
-	ID     int    `json:"id"`
-	Type   string `json:"type"`
-	Events []struct {
-		ID        int    `json:"id"`
-		Interface string `json:"interface"`
-		Branch    int    `json:"branch"`
-		Money     int    `json:"money,omitempty"`
-	} `json:"events"`
+	ID         int             `json:"id"`
+	Type       string          `json:"type"`
+	Guarantees []string        `json:"guarantees,omitempty"`
+	Events     []CustomerEvent `json:"events"`
+}
+
+// CustomerEvent is one request a customer makes at a branch.
+type CustomerEvent struct {
+	ID         int           `json:"id"`
+	Interface  string        `json:"interface"`
+	Branch     int           `json:"branch"`
+	Money      *branch.Money `json:"money,omitempty"`
+	Currency   string        `json:"currency,omitempty"`    // Currency of the balance a query returns
+	ToCurrency string        `json:"to_currency,omitempty"` // Currency a conversion buys
+	ToCustomer int           `json:"to_customer,omitempty"` // Customer a transfer pays
+	From       time.Time     `json:"from,omitempty"`        // Earliest write a statement lists
+	To         time.Time     `json:"to,omitempty"`          // Time before which a statement's writes were made
+	PageSize   int           `json:"page_size,omitempty"`   // Entries a statement fetches per request
 }
 
 type OutputEvent struct {
-	Interface string `json:"interface"`
-	Branch    int    `json:"branch"`
-	Result    string `json:"result,omitempty"`
-	Balance   int    `json:"balance,omitempty"`
+	Interface string                 `json:"interface"`
+	Branch    int                    `json:"branch"`
+	Result    string                 `json:"result,omitempty"`
+	Balance   json.Number            `json:"balance,omitempty"` // Exact decimal amount in major units
+	Currency  string                 `json:"currency,omitempty"`
+	Balances  map[string]json.Number `json:"balances,omitempty"` // Every currency the account holds, if more than one
+	Statement []StatementLine        `json:"statement,omitempty"`
+}
+
+// StatementLine is one write event on a customer's statement.
+type StatementLine struct {
+	ID               int         `json:"id"`        // Event ID of the customer that made the write
+	Interface        string      `json:"interface"` // deposit, withdraw, convert, transfer_out or transfer_in
+	Branch           int         `json:"branch"`    // Branch the write was made at
+	Time             string      `json:"time"`
+	Money            json.Number `json:"money"`
+	Currency         string      `json:"currency"`
+	Balance          json.Number `json:"balance"` // Balance left in the currency of money
+	Converted        json.Number `json:"converted,omitempty"`
+	ToCurrency       string      `json:"to_currency,omitempty"`
+	ConvertedBalance json.Number `json:"converted_balance,omitempty"`
+	Customer         int         `json:"customer,omitempty"` // Other customer of a transfer
 }
 
 type OutputData struct {
@@ -36,17 +75,94 @@ type OutputData struct {
 	Recv []OutputEvent `json:"recv"`
 }
 
+// Session is the state a customer carries from one event to the next: the
+// session token the branches check and the guarantees they should enforce.
+type Session struct {
+	Token      *branch.SessionToken
+	Guarantees []branch.SessionGuarantee
+}
+
+// parseGuarantees maps guarantee names such as "monotonic_reads" to the
+// SessionGuarantee values sent to the branches.
+func parseGuarantees(names []string) ([]branch.SessionGuarantee, error) {
+	var guarantees []branch.SessionGuarantee
+	for _, name := range names {
+		value, ok := branch.SessionGuarantee_value[strings.ToUpper(strings.TrimSpace(name))]
+		if !ok {
+			return nil, fmt.Errorf("unknown session guarantee %q", name)
+		}
+		guarantees = append(guarantees, branch.SessionGuarantee(value))
+	}
+	return guarantees, nil
+}
+
+// mergeVector raises every entry of into to at least the matching entry of from.
+func mergeVector(into map[int32]int64, from map[int32]int64) {
+	for branchID, count := range from {
+		if into[branchID] < count {
+			into[branchID] = count
+		}
+	}
+}
+
+// recordRead adds the write events behind a balance the session read.
+func (session *Session) recordRead(vector map[int32]int64) {
+	mergeVector(session.Token.ReadVector, vector)
+}
+
+// recordWrite adds a write the session made, stamped with vector.
+func (session *Session) recordWrite(vector map[int32]int64) {
+	mergeVector(session.Token.WriteVector, vector)
+}
+
 func main() {
 	// Read customer data from JSON file
-	if len(os.Args) < 2 {
-		fmt.Println("Usage: programName filename")
+	guaranteesFlag := flag.String("guarantees", "", "comma-separated session guarantees for every customer, overriding the input file (read_your_writes, monotonic_reads, monotonic_writes, writes_follow_reads)")
+	resolverSpec := flag.String("resolver", "", "where to find the addresses of branches, which may change while customers run: file:path for a cluster config, dns:domain for SRV records, or registry:dir for a branch registry; the cluster config if unset")
+	clusterFile := flag.String("cluster", "", "cluster config (JSON, or YAML if named .yaml or .yml) listing the host and port of every branch; branch N is on localhost:"+fmt.Sprint(branch_service.DefaultBasePort)+"+N-1 if unset")
+	tlsDir := flag.String("tls-dir", "", "directory of certificates from gencerts; customers reach branches over TLS and present customer.pem if it is there")
+	flag.Parse()
+	if flag.NArg() < 1 {
+		fmt.Println("Usage: programName [-guarantees list] [-cluster file] [-resolver spec] [-tls-dir dir] filename")
 		return
 	}
-	inputFilename := os.Args[1]
+	inputFilename := flag.Arg(0)
 	customerData, err := readCustomerDataFromFile(inputFilename)
 	if err != nil {
 		log.Fatalf("Error reading customer data from file %s : %v", inputFilename, err)
 	}
+
+	// Find where every branch the customers talk to serves
+	var cluster *branch_service.ClusterConfig
+	if *clusterFile != "" {
+		cluster, err = branch_service.LoadClusterConfig(*clusterFile)
+		if err != nil {
+			log.Fatalf("Error reading cluster config: %v", err)
+		}
+	} else {
+		var ids []int32
+		for _, customer := range customerData {
+			for _, event := range customer.Events {
+				ids = append(ids, int32(event.Branch))
+			}
+		}
+		cluster = branch_service.DefaultClusterConfig(ids)
+	}
+	var resolver branch_service.Resolver = cluster
+	if *resolverSpec != "" {
+		resolver, err = branch_service.OpenResolver(*resolverSpec)
+		if err != nil {
+			log.Fatalf("Error opening resolver: %v", err)
+		}
+	}
+	builder := branch_service.NewGRPCResolver(resolver)
+	var identity *branch_service.TLSIdentity
+	if *tlsDir != "" {
+		identity, err = branch_service.LoadTLSIdentity(*tlsDir, branch_service.CustomerIdentity, true)
+		if err != nil {
+			log.Fatalf("Error reading TLS certificates: %v", err)
+		}
+	}
 	// Create a map to store customer clients
 	// customerClients := make(map[int]*branch.BranchServiceClient)
 	outputFilename := "../output.json"
@@ -58,6 +174,16 @@ func main() {
 	}
 	defer outputFile.Close()
 
+	// Dial each branch once, and send it every event that goes to it over
+	// the same connection
+	clients := make(map[int]branch.BranchServiceClient)
+	var conns []*grpc.ClientConn
+	defer func() {
+		for _, conn := range conns {
+			conn.Close()
+		}
+	}()
+
 	encoder := json.NewEncoder(outputFile)
 	outputFile.WriteString("[") // Add the '[' at the beginning
 
@@ -69,23 +195,40 @@ func main() {
 
 		// customerClients[customerID] = client
 
+		// Pick the session guarantees, letting the command line override the input file
+		guaranteeNames := customer.Guarantees
+		if *guaranteesFlag != "" {
+			guaranteeNames = strings.Split(*guaranteesFlag, ",")
+		}
+		guarantees, err := parseGuarantees(guaranteeNames)
+		if err != nil {
+			log.Fatalf("Error reading session guarantees for customer %d: %v", customerID, err)
+		}
+		session := &Session{
+			Token: &branch.SessionToken{
+				WriteVector: make(map[int32]int64),
+				ReadVector:  make(map[int32]int64),
+			},
+			Guarantees: guarantees,
+		}
+
 		// Process customer events and collect results
-		var lastWriteEventID int = -1
 		for _, event := range customer.Events {
 			var results []OutputEvent
-			// Get the address of the branch server corresponding to the customer's ID
-			address := fmt.Sprintf("localhost:%d", 8080+event.Branch-1)
 			// log.Printf("Event branch is %d\n", event.Branch)
-			// // Create a gRPC connection to the branch server
-			client, err := createBranchClient(address)
-			if err != nil {
-				log.Fatalf("Error creating a branch client for customer %d: %v", customerID, err)
+			client, ok := clients[event.Branch]
+			if !ok {
+				// Create a gRPC connection to the branch server, found through the resolver
+				conn, err := dialBranch(branch_service.BranchTarget(int32(event.Branch)), grpc.WithResolvers(builder), branch_service.BranchDialOption(identity, int32(event.Branch)))
+				if err != nil {
+					log.Fatalf("Error creating a branch client for customer %d: %v", customerID, err)
+				}
+				conns = append(conns, conn)
+				client = branch.NewBranchServiceClient(conn)
+				clients[event.Branch] = client
 			}
 
-			result := processCustomerEvent(*client, customerID, event, lastWriteEventID)
-			if event.Interface == "deposit" || event.Interface == "withdraw" {
-				lastWriteEventID = event.ID
-			}
+			result := processCustomerEvent(client, customerID, event, session)
 			log.Printf("result for customer %d and event id is %d, result %v\n", customer.ID, event.ID, result)
 			// Write the results in the specified format
 			results = append(results, result)
@@ -116,7 +259,10 @@ func readCustomerDataFromFile(filename string) ([]Customer, error) {
 		return nil, fmt.Errorf("error reading customer data file: %v", err)
 	}
 
-	err = json.Unmarshal(fileContents, &data)
+	// Keep numbers as text so that amounts are parsed exactly
+	decoder := json.NewDecoder(bytes.NewReader(fileContents))
+	decoder.UseNumber()
+	err = decoder.Decode(&data)
 	if err != nil {
 		return nil, fmt.Errorf("error unmarshaling customer data: %v", err)
 	}
@@ -126,40 +272,64 @@ func readCustomerDataFromFile(filename string) ([]Customer, error) {
 	for _, entry := range data {
 		if entryType, ok := entry["type"].(string); ok {
 			if entryType == "customer" {
-				if id, ok := entry["id"].(float64); ok {
-					var events []struct {
-						ID        int    `json:"id"`
-						Interface string `json:"interface"`
-						Branch    int    `json:"branch"`
-						Money     int    `json:"money,omitempty"`
-					}
+				if id, ok := entry["id"].(json.Number); ok {
+					var events []CustomerEvent
 					if eventsData, ok := entry["events"].([]interface{}); ok {
 						for _, eventData := range eventsData {
 							event, ok := eventData.(map[string]interface{})
 							if ok {
-								eventID, _ := event["id"].(float64)
+								eventID := intField(event, "id")
 								eventInterface, _ := event["interface"].(string)
-								eventMoney, _ := event["money"].(float64)
-								eventBranch, _ := event["branch"].(float64)
-								events = append(events, struct {
-									ID        int    `json:"id"`
-									Interface string `json:"interface"`
-									Branch    int    `json:"branch"`
-									Money     int    `json:"money,omitempty"`
-								}{
-									ID:        int(eventID),
-									Interface: eventInterface,
-									Branch:    int(eventBranch),
-									Money:     int(eventMoney),
+								eventBranch := intField(event, "branch")
+								eventCurrency, _ := event["currency"].(string)
+								eventToCurrency, _ := event["to_currency"].(string)
+								eventToCustomer := intField(event, "to_customer")
+								eventFrom, err := timeField(event, "from")
+								if err != nil {
+									return nil, fmt.Errorf("error reading event %d of customer %s: %v", eventID, id, err)
+								}
+								eventTo, err := timeField(event, "to")
+								if err != nil {
+									return nil, fmt.Errorf("error reading event %d of customer %s: %v", eventID, id, err)
+								}
+								var eventMoney *branch.Money
+								if amount, ok := event["money"].(json.Number); ok {
+									eventMoney, err = branch_service.ParseMoney(amount.String(), eventCurrency)
+									if err != nil {
+										return nil, fmt.Errorf("error reading money of event %d of customer %s: %v", eventID, id, err)
+									}
+								}
+								events = append(events, CustomerEvent{
+									ID:         eventID,
+									Interface:  eventInterface,
+									Branch:     eventBranch,
+									Money:      eventMoney,
+									Currency:   eventCurrency,
+									ToCurrency: eventToCurrency,
+									ToCustomer: eventToCustomer,
+									From:       eventFrom,
+									To:         eventTo,
+									PageSize:   intField(event, "page_size"),
 								})
 							}
 						}
 					}
 
+					var guarantees []string
+					if guaranteesData, ok := entry["guarantees"].([]interface{}); ok {
+						for _, guarantee := range guaranteesData {
+							if name, ok := guarantee.(string); ok {
+								guarantees = append(guarantees, name)
+							}
+						}
+					}
+
+					customerID, _ := id.Int64()
 					customer := Customer{
-						ID:     int(id),
-						Type:   entryType,
-						Events: events,
+						ID:         int(customerID),
+						Type:       entryType,
+						Guarantees: guarantees,
+						Events:     events,
 					}
 					customers = append(customers, customer)
 				}
@@ -170,53 +340,220 @@ func readCustomerDataFromFile(filename string) ([]Customer, error) {
 	return customers, nil
 }
 
-func createBranchClient(address string) (*branch.BranchServiceClient, error) {
-	// Create a gRPC connection to the branch server
-	conn, err := grpc.Dial(address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+// intField reads an integer field of an input entry, or 0 if it is missing.
+func intField(entry map[string]interface{}, name string) int {
+	number, _ := entry[name].(json.Number)
+	value, _ := number.Int64()
+	return int(value)
+}
+
+// timeField reads a time field of an input entry, either RFC 3339 or a date,
+// or the zero time if it is missing.
+func timeField(entry map[string]interface{}, name string) (time.Time, error) {
+	text, ok := entry[name].(string)
+	if !ok {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, text); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse(time.DateOnly, text)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%s is neither an RFC 3339 time nor a date: %q", name, text)
+	}
+	return t, nil
+}
+
+// dialBranch creates a gRPC connection to a branch server. Each connection
+// resolves its target with a watch of its own, which runs until the
+// connection is closed.
+func dialBranch(target string, opts ...grpc.DialOption) (*grpc.ClientConn, error) {
+	conn, err := grpc.Dial(target, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to branch server: %v", err)
 	}
+	return conn, nil
+}
 
-	// Create a branch client
-	client := branch.NewBranchServiceClient(conn)
-	return &client, nil
+// withRetry calls send until it succeeds, fails with an error that is not
+// transient, or runs out of attempts. Writes are safe to retry because the
+// branches recognise the repeated event ID and do not apply it twice.
+func withRetry(send func() error) error {
+	backoff := 200 * time.Millisecond
+	var err error
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		err = send()
+		if code := status.Code(err); code != codes.Unavailable && code != codes.DeadlineExceeded {
+			return err
+		}
+		if attempt < maxAttempts {
+			time.Sleep(backoff)
+			backoff *= 2
+		}
+	}
+	return err
 }
 
-func processCustomerEvent(client branch.BranchServiceClient, customerID int, event struct {
-	ID        int    `json:"id"`
-	Interface string `json:"interface"`
-	Branch    int    `json:"branch"`
-	Money     int    `json:"money,omitempty"`
-}, lastWriteEventID int) OutputEvent {
+func processCustomerEvent(client branch.BranchServiceClient, customerID int, event CustomerEvent, session *Session) OutputEvent {
 	switch event.Interface {
 	case "query":
 		// Process query event
-		queryResponse, err := client.QueryBalance(context.Background(), &branch.QueryBalanceRequest{LastWriteEventID: int32(lastWriteEventID)})
+		var queryResponse *branch.QueryBalanceResponse
+		err := withRetry(func() (err error) {
+			queryResponse, err = client.QueryBalance(context.Background(), &branch.QueryBalanceRequest{CustomerId: int32(customerID), CurrencyCode: event.Currency, Session: session.Token, Guarantees: session.Guarantees})
+			return err
+		})
 		if err != nil {
 			log.Printf("Error querying balance for customer %d: %v", customerID, err)
-			return OutputEvent{Interface: "query", Branch: event.Branch, Balance: 0}
+			return OutputEvent{Interface: "query", Branch: event.Branch}
+		}
+		session.recordRead(queryResponse.Vector)
+		output := OutputEvent{Interface: "query", Branch: event.Branch, Balance: json.Number(branch_service.FormatMoney(queryResponse.Money)), Currency: queryResponse.Money.GetCurrencyCode()}
+		if len(queryResponse.Balances) > 1 {
+			output.Balances = make(map[string]json.Number)
+			for _, balance := range queryResponse.Balances {
+				output.Balances[balance.CurrencyCode] = json.Number(branch_service.FormatMoney(balance))
+			}
 		}
-		return OutputEvent{Interface: "query", Branch: event.Branch, Balance: int(queryResponse.Balance)}
+		return output
 
 	case "deposit":
 		// Process deposit event
-		_, err := client.Deposit(context.Background(), &branch.DepositRequest{Amount: float32(event.Money), WriteEventID: int32(event.ID)})
+		var depositResponse *branch.DepositResponse
+		err := withRetry(func() (err error) {
+			depositResponse, err = client.Deposit(context.Background(), &branch.DepositRequest{CustomerId: int32(customerID), Money: event.Money, WriteEventID: int32(event.ID), Session: session.Token, Guarantees: session.Guarantees})
+			return err
+		})
 		if err != nil {
 			log.Printf("Error depositing money for customer %d: %v", customerID, err)
 			return OutputEvent{Interface: "deposit", Result: "error"}
 		}
+		session.recordWrite(depositResponse.Vector)
 		return OutputEvent{Interface: "deposit", Branch: event.Branch, Result: "success"}
 
 	case "withdraw":
 		// Process withdraw event
-		_, err := client.Withdraw(context.Background(), &branch.WithdrawRequest{Amount: float32(event.Money), WriteEventID: int32(event.ID)})
+		var withdrawResponse *branch.WithdrawResponse
+		err := withRetry(func() (err error) {
+			withdrawResponse, err = client.Withdraw(context.Background(), &branch.WithdrawRequest{CustomerId: int32(customerID), Money: event.Money, WriteEventID: int32(event.ID), Session: session.Token, Guarantees: session.Guarantees})
+			return err
+		})
 		if err != nil {
 			log.Printf("Error withdrawing money for customer %d: %v", customerID, err)
 			return OutputEvent{Interface: "withdraw", Result: "error"}
 		}
+		session.recordWrite(withdrawResponse.Vector)
 		return OutputEvent{Interface: "withdraw", Branch: event.Branch, Result: "success"}
+
+	case "convert":
+		// Process currency conversion event
+		var convertResponse *branch.ConvertCurrencyResponse
+		err := withRetry(func() (err error) {
+			convertResponse, err = client.ConvertCurrency(context.Background(), &branch.ConvertCurrencyRequest{CustomerId: int32(customerID), Money: event.Money, ToCurrency: event.ToCurrency, WriteEventID: int32(event.ID), Session: session.Token, Guarantees: session.Guarantees})
+			return err
+		})
+		if err != nil {
+			log.Printf("Error converting money for customer %d: %v", customerID, err)
+			return OutputEvent{Interface: "convert", Result: "error"}
+		}
+		session.recordWrite(convertResponse.Vector)
+		return OutputEvent{Interface: "convert", Branch: event.Branch, Result: "success", Balance: json.Number(branch_service.FormatMoney(convertResponse.ConvertedBalance)), Currency: convertResponse.ConvertedBalance.GetCurrencyCode()}
+
+	case "transfer":
+		// Process transfer event
+		var transferResponse *branch.TransferResponse
+		err := withRetry(func() (err error) {
+			transferResponse, err = client.Transfer(context.Background(), &branch.TransferRequest{CustomerId: int32(customerID), TargetCustomerId: int32(event.ToCustomer), Money: event.Money, WriteEventID: int32(event.ID), Session: session.Token, Guarantees: session.Guarantees})
+			return err
+		})
+		if err != nil {
+			log.Printf("Error transferring money from customer %d to customer %d: %v", customerID, event.ToCustomer, err)
+			return OutputEvent{Interface: "transfer", Result: "error"}
+		}
+		session.recordWrite(transferResponse.Vector)
+		return OutputEvent{Interface: "transfer", Branch: event.Branch, Result: "success"}
+
+	case "statement":
+		// Process statement event
+		statement, err := fetchStatement(client, customerID, event, session)
+		if err != nil {
+			log.Printf("Error fetching the statement of customer %d: %v", customerID, err)
+			return OutputEvent{Interface: "statement", Branch: event.Branch, Result: "error"}
+		}
+		return OutputEvent{Interface: "statement", Branch: event.Branch, Result: "success", Statement: statement}
 	}
 
 	log.Printf("Unknown event type for customer ID %d: %s\n", customerID, event.Interface)
 	return OutputEvent{} // Default empty result
 }
+
+// fetchStatement reads the customer's statement from the branch page by page.
+func fetchStatement(client branch.BranchServiceClient, customerID int, event CustomerEvent, session *Session) ([]StatementLine, error) {
+	pageSize := event.PageSize
+	if pageSize <= 0 {
+		pageSize = branch_service.DefaultStatementPageSize
+	}
+	if pageSize > branch_service.MaxStatementPageSize {
+		pageSize = branch_service.MaxStatementPageSize
+	}
+	request := &branch.StatementRequest{
+		CustomerId: int32(customerID),
+		PageSize:   int32(pageSize),
+		Session:    session.Token,
+		Guarantees: session.Guarantees,
+	}
+	if !event.From.IsZero() {
+		request.FromTime = event.From.UnixMilli()
+	}
+	if !event.To.IsZero() {
+		request.ToTime = event.To.UnixMilli()
+	}
+
+	statement := []StatementLine{}
+	for {
+		var page []*branch.StatementEntry
+		err := withRetry(func() error {
+			page = nil
+			stream, err := client.GetStatement(context.Background(), request)
+			if err != nil {
+				return err
+			}
+			for {
+				entry, err := stream.Recv()
+				if err == io.EOF {
+					return nil
+				}
+				if err != nil {
+					return err
+				}
+				page = append(page, entry)
+			}
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, entry := range page {
+			line := StatementLine{
+				ID:        int(entry.WriteEventID),
+				Interface: strings.ToLower(entry.Kind.String()),
+				Branch:    int(entry.OriginBranch),
+				Time:      time.UnixMilli(entry.Timestamp).UTC().Format(time.RFC3339Nano),
+				Money:     json.Number(branch_service.FormatMoney(entry.Money)),
+				Currency:  entry.Money.GetCurrencyCode(),
+				Balance:   json.Number(branch_service.FormatMoney(entry.Balance)),
+				Customer:  int(entry.CounterpartyCustomerId),
+			}
+			if entry.Converted != nil {
+				line.Converted = json.Number(branch_service.FormatMoney(entry.Converted))
+				line.ToCurrency = entry.Converted.CurrencyCode
+				line.ConvertedBalance = json.Number(branch_service.FormatMoney(entry.ConvertedBalance))
+			}
+			statement = append(statement, line)
+		}
+		if len(page) < pageSize {
+			return statement, nil
+		}
+		request.AfterPosition = page[len(page)-1].Position
+	}
+}
@@ -0,0 +1,187 @@
+package main
+
+import (
+	"branch_service"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"flag"
+	"fmt"
+	"log"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// loadOrCreateCA reads the CA from the directory, or creates one if there is
+// none yet, so that branches added later are signed by the same CA.
+func loadOrCreateCA(dir string, validity time.Duration) (*x509.Certificate, *ecdsa.PrivateKey, error) {
+	certFile := filepath.Join(dir, branch_service.CAFile)
+	keyFile := filepath.Join(dir, "ca-key.pem")
+	certPEM, err := os.ReadFile(certFile)
+	if err == nil {
+		keyPEM, err := os.ReadFile(keyFile)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error reading CA key: %v", err)
+		}
+		certBlock, _ := pem.Decode(certPEM)
+		keyBlock, _ := pem.Decode(keyPEM)
+		if certBlock == nil || keyBlock == nil {
+			return nil, nil, fmt.Errorf("%s or %s is not PEM", certFile, keyFile)
+		}
+		cert, err := x509.ParseCertificate(certBlock.Bytes)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error parsing CA certificate: %v", err)
+		}
+		key, err := x509.ParseECPrivateKey(keyBlock.Bytes)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error parsing CA key: %v", err)
+		}
+		fmt.Printf("Using the CA in %s\n", certFile)
+		return cert, key, nil
+	}
+	if !os.IsNotExist(err) {
+		return nil, nil, fmt.Errorf("error reading CA certificate: %v", err)
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, err
+	}
+	template := &x509.Certificate{
+		SerialNumber:          serialNumber(),
+		Subject:               pkix.Name{CommonName: "banking branch CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(validity),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return nil, nil, err
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := writeKeyPair(dir, "ca", der, key); err != nil {
+		return nil, nil, err
+	}
+	fmt.Printf("Created a CA in %s\n", certFile)
+	return cert, key, nil
+}
+
+// issue signs a certificate named name, as its common name and DNS name,
+// that is also valid for the given hosts. Branches serve with theirs and
+// present it to their peers; customers only present theirs.
+func issue(dir, name string, hosts []string, server bool, ca *x509.Certificate, caKey *ecdsa.PrivateKey, validity time.Duration) error {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return err
+	}
+	template := &x509.Certificate{
+		SerialNumber: serialNumber(),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(validity),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		DNSNames:     []string{name},
+	}
+	if server {
+		template.ExtKeyUsage = append(template.ExtKeyUsage, x509.ExtKeyUsageServerAuth)
+		for _, host := range hosts {
+			if ip := net.ParseIP(host); ip != nil {
+				template.IPAddresses = append(template.IPAddresses, ip)
+			} else {
+				template.DNSNames = append(template.DNSNames, host)
+			}
+		}
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca, &key.PublicKey, caKey)
+	if err != nil {
+		return err
+	}
+	if err := writeKeyPair(dir, name, der, key); err != nil {
+		return err
+	}
+	fmt.Printf("Created the certificate of %s\n", name)
+	return nil
+}
+
+// writeKeyPair writes a certificate to <name>.pem and its key, readable
+// only by the owner, to <name>-key.pem.
+func writeKeyPair(dir, name string, der []byte, key *ecdsa.PrivateKey) error {
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return err
+	}
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	if err := os.WriteFile(filepath.Join(dir, name+".pem"), certPEM, 0644); err != nil {
+		return err
+	}
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	return os.WriteFile(filepath.Join(dir, name+"-key.pem"), keyPEM, 0600)
+}
+
+func serialNumber() *big.Int {
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		log.Fatalf("Error generating a serial number: %v", err)
+	}
+	return serial
+}
+
+func main() {
+	out := flag.String("out", "certs", "directory to write the CA and certificates to; a CA already there is reused")
+	branches := flag.String("branches", "", "comma-separated IDs of the branches to create certificates for")
+	hosts := flag.String("hosts", "localhost,127.0.0.1", "comma-separated host names and IP addresses the branches serve at")
+	customer := flag.Bool("customer", true, "also create the certificate customers present")
+	validity := flag.Duration("validity", 365*24*time.Hour, "how long the certificates are valid for")
+	flag.Parse()
+	if *branches == "" {
+		fmt.Println("Usage: programName -branches ids [-out dir] [-hosts list] [-customer=false] [-validity duration]")
+		return
+	}
+
+	var ids []int32
+	for _, text := range strings.Split(*branches, ",") {
+		id, err := strconv.ParseInt(strings.TrimSpace(text), 10, 32)
+		if err != nil || id <= 0 {
+			log.Fatalf("Branch ID %q is not a positive number", text)
+		}
+		ids = append(ids, int32(id))
+	}
+	var hostList []string
+	for _, host := range strings.Split(*hosts, ",") {
+		if host = strings.TrimSpace(host); host != "" {
+			hostList = append(hostList, host)
+		}
+	}
+
+	if err := os.MkdirAll(*out, 0755); err != nil {
+		log.Fatalf("Error creating certificate directory: %v", err)
+	}
+	ca, caKey, err := loadOrCreateCA(*out, *validity)
+	if err != nil {
+		log.Fatalf("Error creating the CA: %v", err)
+	}
+	for _, id := range ids {
+		if err := issue(*out, branch_service.BranchIdentity(id), hostList, true, ca, caKey, *validity); err != nil {
+			log.Fatalf("Error creating the certificate of branch %d: %v", id, err)
+		}
+	}
+	if *customer {
+		if err := issue(*out, branch_service.CustomerIdentity, nil, false, ca, caKey, *validity); err != nil {
+			log.Fatalf("Error creating the certificate of customers: %v", err)
+		}
+	}
+}
@@ -1,3 +1,13 @@
 #! /bin/bash
 
-kill -9 $(pgrep "start_branch_servers")
\ No newline at end of file
+# Ask the branches to stop gracefully, and kill them if they are still
+# running after a while
+pattern='^(\S*/)?start_branch_servers( |$)'
+pids=$(pgrep -f "$pattern")
+[ -z "$pids" ] && exit 0
+kill -TERM $pids
+for i in $(seq 1 30); do
+    sleep 0.5
+    pgrep -f "$pattern" > /dev/null || exit 0
+done
+kill -9 $(pgrep -f "$pattern") 2>/dev/null
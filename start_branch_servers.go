@@ -5,14 +5,23 @@ import (
 
 	"branch_service"
 	"branch_service/branch"
+	"bytes"
+	"context"
 	"encoding/json"
+	"flag"
 	"fmt"
 	"log"
+	"net"
 	"os"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"strings"
 	"sync"
+	"syscall"
+	"time"
 
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
 )
 
 func readBranchDataFromFile(filename string) ([]*branch.Branch, error) {
@@ -24,7 +33,10 @@ func readBranchDataFromFile(filename string) ([]*branch.Branch, error) {
 		return nil, fmt.Errorf("error reading branch data file: %v", err)
 	}
 
-	err = json.Unmarshal(fileContents, &data)
+	// Keep numbers as text so that balances are parsed exactly
+	decoder := json.NewDecoder(bytes.NewReader(fileContents))
+	decoder.UseNumber()
+	err = decoder.Decode(&data)
 	if err != nil {
 		return nil, fmt.Errorf("error unmarshaling branch data: %v", err)
 	}
@@ -34,11 +46,19 @@ func readBranchDataFromFile(filename string) ([]*branch.Branch, error) {
 	for _, entry := range data {
 		if entryType, ok := entry["type"].(string); ok {
 			if entryType == "branch" {
-				if balance, ok := entry["balance"].(float64); ok {
-					id, _ := entry["id"].(float64)
+				if balance, ok := entry["balance"].(json.Number); ok {
+					idNumber, _ := entry["id"].(json.Number)
+					id, _ := idNumber.Int64()
+					currency, _ := entry["currency"].(string)
+					store, _ := entry["store"].(string)
+					openingBalance, err := branch_service.ParseMoney(balance.String(), currency)
+					if err != nil {
+						return nil, fmt.Errorf("error reading balance of branch %d: %v", id, err)
+					}
 					branch := &branch.Branch{
-						Id:      int32(id),
-						Balance: float32(balance),
+						Id:             int32(id),
+						OpeningBalance: openingBalance,
+						Store:          store,
 					}
 					branches = append(branches, branch)
 				}
@@ -46,12 +66,19 @@ func readBranchDataFromFile(filename string) ([]*branch.Branch, error) {
 		}
 	}
 
+	// Every branch replicates every account, so they must agree on its currency
+	for _, b := range branches {
+		if b.OpeningBalance.CurrencyCode != branches[0].OpeningBalance.CurrencyCode {
+			return nil, fmt.Errorf("branch %d keeps accounts in %s but branch %d in %s", b.Id, b.OpeningBalance.CurrencyCode, branches[0].Id, branches[0].OpeningBalance.CurrencyCode)
+		}
+	}
+
 	return branches, nil
 }
 
-func createBranchClient(address string) (branch.BranchServiceClient, error) {
+func createBranchClient(target string, opts ...grpc.DialOption) (branch.BranchServiceClient, error) {
 	// Create a gRPC connection to the branch server
-	conn, err := grpc.Dial(address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	conn, err := grpc.Dial(target, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to branch server: %v", err)
 	}
@@ -61,63 +88,341 @@ func createBranchClient(address string) (branch.BranchServiceClient, error) {
 	return client, nil
 }
 
+// readyBranch is where a branch of this process listens, as the ready line
+// and ready file report it.
+type readyBranch struct {
+	ID      int32  `json:"id"`
+	Address string `json:"address"`
+}
+
+// startBranch starts a branch server, and returns where it listens.
+func startBranch(data *branch.Branch, server *branch_service.BranchServer) readyBranch {
+	if _, err := server.StartBranchServer(); err != nil {
+		log.Fatalf("Error starting branch %d: %v", data.Id, err)
+	}
+	fmt.Printf("Starting branch server for ID: %d, Initial Balance: %s %s at: %s\n", data.Id, branch_service.FormatMoney(data.OpeningBalance), data.OpeningBalance.CurrencyCode, server.Address)
+	return readyBranch{ID: data.Id, Address: server.Address}
+}
+
+// reportReady prints a line of "READY" and the JSON list of the branches
+// once every one is listening, and writes the same JSON to the ready file if
+// there is one. The file is replaced atomically, so a harness waiting for it
+// never reads half of it.
+func reportReady(branches []readyBranch, path string) {
+	data, err := json.Marshal(struct {
+		Branches []readyBranch `json:"branches"`
+	}{branches})
+	if err != nil {
+		log.Fatalf("Error encoding ready branches: %v", err)
+	}
+	if path != "" {
+		tmp := path + ".tmp"
+		if err := os.WriteFile(tmp, append(data, '\n'), 0644); err != nil {
+			log.Fatalf("Error writing ready file: %v", err)
+		}
+		if err := os.Rename(tmp, path); err != nil {
+			log.Fatalf("Error writing ready file: %v", err)
+		}
+	}
+	fmt.Printf("READY %s\n", data)
+}
+
+// stopOnSignal blocks until the process is interrupted or terminated, and
+// then stops every branch gracefully, cutting short what is left after the
+// timeout.
+func stopOnSignal(signals <-chan os.Signal, servers map[int32]*branch_service.BranchServer, timeout time.Duration) {
+	sig := <-signals
+	log.Printf("Received %v, stopping branch servers", sig)
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	var wg sync.WaitGroup
+	for id, server := range servers {
+		wg.Add(1)
+		go func(id int32, server *branch_service.BranchServer) {
+			defer wg.Done()
+			if err := server.Stop(ctx); err != nil {
+				log.Printf("Error stopping branch %d: %v", id, err)
+			}
+		}(id, server)
+	}
+	wg.Wait()
+}
+
 func main() {
 	// Read branch data from JSON file
 	log.SetOutput(os.Stdout)
-	if len(os.Args) < 2 {
-		fmt.Println("Usage: programName filename")
+	maxQueryWait := flag.Duration("max-query-wait", branch_service.DefaultMaxQueryWait, "longest a request waits for the writes its customer's session depends on")
+	dedupWindow := flag.Int("dedup-window", branch_service.DefaultDedupWindow, "how many recent write events each branch remembers to answer retries")
+	antiEntropyInterval := flag.Duration("anti-entropy-interval", branch_service.DefaultAntiEntropyInterval, "how often each branch pulls missing write events from its peers")
+	propagation := flag.String("propagation", "sync_all", "how long a write waits for peers: sync_all, sync_quorum or async")
+	propagateTimeout := flag.Duration("propagate-timeout", branch_service.DefaultPropagateTimeout, "deadline of each attempt to deliver a write to a peer")
+	withdrawals := flag.String("withdrawals", "local", "where withdrawals are checked: local, or primary to never overdraw an account")
+	raft := flag.Bool("raft", false, "replicate writes through a Raft log and read balances linearizably from the leader")
+	dataDir := flag.String("data-dir", "", "directory to keep each branch's write-ahead log and snapshots in, under branch-<id>; state is kept in memory only if unset")
+	snapshotInterval := flag.Duration("snapshot-interval", branch_service.DefaultSnapshotInterval, "how often each branch with a data directory snapshots its state")
+	fxRates := flag.String("fx-rates", "", "file of exchange rates for currency conversions, one \"FROM TO RATE\" per line")
+	storeKind := flag.String("store", "memory", "where branches without a \"store\" in the input file keep their state: "+strings.Join(branch_service.StoreKinds, ", ")+"; bolt and sqlite need -data-dir")
+	gossipInterval := flag.Duration("gossip-interval", branch_service.DefaultGossipInterval, "how often each branch probes a peer to tell whether it is alive")
+	suspectTimeout := flag.Duration("suspect-timeout", branch_service.DefaultSuspectTimeout, "how long a peer that missed a probe has to answer before it is declared dead")
+	clusterFile := flag.String("cluster", "", "cluster config (JSON, or YAML if named .yaml or .yml) listing the host and port of every branch; branch N is on localhost:"+fmt.Sprint(branch_service.DefaultBasePort)+"+N-1 if unset")
+	branchID := flag.Int("branch", 0, "run only this branch of the input file in this process, and reach the others through the cluster config")
+	resolverSpec := flag.String("resolver", "", "where to find the addresses of peers, which may change while branches run: file:path for a cluster config, dns:domain for SRV records, or registry:dir for a directory every branch registers itself in; the cluster config if unset")
+	stopTimeout := flag.Duration("stop-timeout", branch_service.DefaultStopTimeout, "how long branches may take to finish requests and deliver writes when the process is interrupted or terminated, or when one leaves with -leave")
+	readyFile := flag.String("ready-file", "", "file to write once every branch is listening, with the address of each as JSON; removed when the branches stop")
+	join := flag.String("join", "", "address of a running branch whose cluster the branches in the input file join, instead of forming their own")
+	leave := flag.String("leave", "", "address of a running branch to take out of its cluster; no input file is read")
+	tlsDir := flag.String("tls-dir", "", "directory of certificates from gencerts; branches serve TLS and authenticate each other with mutual TLS if set, and -leave needs -branch to pick the branch's certificate")
+	flag.Parse()
+	if *leave != "" {
+		var identity *branch_service.TLSIdentity
+		if *tlsDir != "" {
+			if *branchID == 0 {
+				log.Fatalf("Leaving with -tls-dir needs -branch, the ID of the branch that leaves")
+			}
+			var err error
+			identity, err = branch_service.LoadTLSIdentity(*tlsDir, branch_service.BranchIdentity(int32(*branchID)), false)
+			if err != nil {
+				log.Fatalf("Error reading TLS certificates: %v", err)
+			}
+		}
+		client, err := createBranchClient(*leave, branch_service.BranchDialOption(identity, int32(*branchID)))
+		if err != nil {
+			log.Fatalf("Error creating a branch client for the branch: %v", err)
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), *stopTimeout)
+		defer cancel()
+		if _, err := client.LeaveCluster(ctx, &branch.LeaveClusterRequest{}); err != nil {
+			log.Fatalf("Error leaving the cluster: %v", err)
+		}
+		fmt.Printf("Branch at %s left the cluster\n", *leave)
 		return
 	}
-	inputFilename := os.Args[1]
+	if flag.NArg() < 1 {
+		fmt.Println("Usage: programName [-max-query-wait duration] [-dedup-window n] [-anti-entropy-interval duration] [-propagation mode] [-propagate-timeout duration] [-withdrawals mode] [-raft] [-data-dir dir] [-snapshot-interval duration] [-fx-rates file] [-store kind] [-gossip-interval duration] [-suspect-timeout duration] [-cluster file] [-branch id] [-resolver spec] [-stop-timeout duration] [-ready-file file] [-tls-dir dir] [-join address] filename")
+		fmt.Println("       programName [-tls-dir dir -branch id] -leave address")
+		return
+	}
+	if *raft && *join != "" {
+		log.Fatalf("A Raft cluster cannot change its members")
+	}
+	propagationMode, ok := branch.PropagationMode_value[strings.ToUpper(*propagation)]
+	if !ok {
+		log.Fatalf("Unknown propagation mode %q", *propagation)
+	}
+	withdrawalMode, err := branch_service.ParseWithdrawalMode(*withdrawals)
+	if err != nil {
+		log.Fatalf("Error reading withdrawal mode: %v", err)
+	}
+	var rates branch_service.RateProvider
+	if *fxRates != "" {
+		rates, err = branch_service.LoadRatesFile(*fxRates)
+		if err != nil {
+			log.Fatalf("Error reading exchange rates: %v", err)
+		}
+	}
+	inputFilename := flag.Arg(0)
 	branchData, err := readBranchDataFromFile(inputFilename)
 	fmt.Print(branchData)
 	if err != nil {
 		log.Fatalf("Error reading branch data: %v", err)
 	}
+
+	// Find where every branch serves
+	var cluster *branch_service.ClusterConfig
+	if *clusterFile != "" {
+		cluster, err = branch_service.LoadClusterConfig(*clusterFile)
+		if err != nil {
+			log.Fatalf("Error reading cluster config: %v", err)
+		}
+	} else {
+		var ids []int32
+		for _, data := range branchData {
+			ids = append(ids, data.Id)
+		}
+		cluster = branch_service.DefaultClusterConfig(ids)
+	}
+	if *branchID != 0 {
+		var selected []*branch.Branch
+		for _, data := range branchData {
+			if data.Id == int32(*branchID) {
+				selected = append(selected, data)
+			}
+		}
+		if len(selected) == 0 {
+			log.Fatalf("Branch %d is not in the input file", *branchID)
+		}
+		branchData = selected
+	}
+
+	// Stop the branches gracefully on SIGINT or SIGTERM
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+
 	// Create a map to store branch servers and their clients
 	branchServers := make(map[int32]*branch_service.BranchServer)
 	branchClients := make(map[int32]branch.BranchServiceClient)
-	// Use a wait group to ensure all servers and clients are initialized
-	var wg sync.WaitGroup
 
 	for _, data := range branchData {
-		wg.Add(1) // Increment the wait group counter
-		port := 8080 + data.Id - 1
-		// Start the branch server
-		server := branch_service.NewBranchServer(data.Id, data.Balance, port)
-		go func(data *branch.Branch, server *branch_service.BranchServer, port int32) {
-			defer wg.Done() // Decrement the wait group counter when done
-			fmt.Printf("Starting branch server for ID: %d, Initial Balance: %.2f on port: %d\n", data.Id, data.Balance, port)
-			server.StartBranchServer()
+		location, err := cluster.Branch(data.Id)
+		if err != nil {
+			log.Fatalf("Error finding where branch %d serves: %v", data.Id, err)
+		}
+		// Create the branch server
+		server := branch_service.NewBranchServer(data.Id, data.OpeningBalance, location.Port)
+		server.Address = location.Address()
+		server.MaxQueryWait = *maxQueryWait
+		server.SetDedupWindow(*dedupWindow)
+		server.AntiEntropyInterval = *antiEntropyInterval
+		server.PropagationMode = branch.PropagationMode(propagationMode)
+		server.PropagateTimeout = *propagateTimeout
+		server.WithdrawalMode = withdrawalMode
+		server.Rates = rates
+		server.SnapshotInterval = *snapshotInterval
+		server.GossipInterval = *gossipInterval
+		server.SuspectTimeout = *suspectTimeout
+		if *raft {
+			server.EnableRaft()
+		}
+		if *tlsDir != "" {
+			server.TLS, err = branch_service.LoadTLSIdentity(*tlsDir, branch_service.BranchIdentity(data.Id), false)
 			if err != nil {
-				log.Printf("Error starting branch server: %v", err)
+				log.Fatalf("Error reading TLS certificates of branch %d: %v", data.Id, err)
+			}
+		}
+		kind := strings.ToLower(data.Store)
+		if kind == "" {
+			kind = *storeKind
+		}
+		branchDir := filepath.Join(*dataDir, fmt.Sprintf("branch-%d", data.Id))
+		switch {
+		case kind != "memory":
+			// The store keeps the branch's state on disk by itself
+			if *dataDir == "" {
+				log.Fatalf("Branch %d needs -data-dir to keep its %s store in", data.Id, kind)
+			}
+			if err := os.MkdirAll(branchDir, 0755); err != nil {
+				log.Fatalf("Error creating data directory of branch %d: %v", data.Id, err)
 			}
-		}(data, server, port)
+			store, err := branch_service.OpenStore(kind, branchDir)
+			if err != nil {
+				log.Fatalf("Error opening store of branch %d: %v", data.Id, err)
+			}
+			if err := server.SetStore(store); err != nil {
+				log.Fatalf("Error restoring branch %d: %v", data.Id, err)
+			}
+		case *dataDir != "":
+			if err := server.OpenDataDir(branchDir); err != nil {
+				log.Fatalf("Error restoring branch %d: %v", data.Id, err)
+			}
+		}
 
 		// Register the branch server
 		branchServers[data.Id] = server
+	}
 
-		// Create a client for the branch
-		client, err := createBranchClient(fmt.Sprintf("localhost:%d", port))
+	if *join != "" {
+		// Join the branches one at a time, since each must be serving before
+		// the next one joins through the cluster
+		var ready []readyBranch
+		for _, data := range branchData {
+			server := branchServers[data.Id]
+			if err := server.Join(context.Background(), *join); err != nil {
+				log.Fatalf("Error joining branch %d to the cluster: %v", data.Id, err)
+			}
+			ready = append(ready, startBranch(data, server))
+		}
+		reportReady(ready, *readyFile)
+		stopOnSignal(signals, branchServers, *stopTimeout)
+		if *readyFile != "" {
+			os.Remove(*readyFile)
+		}
+		return
+	}
+
+	// Find peers through the resolver, which the branches here register
+	// in once they listen if it is a registry
+	var resolver branch_service.Resolver = cluster
+	if *resolverSpec != "" {
+		resolver, err = branch_service.OpenResolver(*resolverSpec)
 		if err != nil {
-			log.Fatalf("Error creating a branch client for the  branch: %v", err)
+			log.Fatalf("Error opening resolver: %v", err)
 		}
-		branchClients[data.Id] = client
-		// Increment the port for the next branch server
-		port++
 	}
-	// Wait for all branch servers and clients to be initialized
-	wg.Wait()
+	registry, _ := resolver.(*branch_service.FileRegistry)
+	if registry == nil {
+		for _, data := range branchData {
+			if location, _ := cluster.Branch(data.Id); location.Port == 0 {
+				log.Printf("Branch %d listens on a port chosen when it starts, which its peers only learn through -resolver registry:dir", data.Id)
+			}
+		}
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	peerIDs, err := resolver.BranchIDs(ctx)
+	if err != nil {
+		log.Fatalf("Error listing branches: %v", err)
+	}
+	for _, location := range cluster.Branches {
+		peerIDs = append(peerIDs, location.ID)
+	}
 
-	// Register peers and establish connections between branches
+	// Create a client for every branch of the cluster, including those that
+	// run in other processes. Each connection follows its branch when the
+	// resolver finds it at a new address. With TLS, every branch here dials
+	// its peers with its own certificate, so the branches only share clients
+	// without it.
+	builder := branch_service.NewGRPCResolver(resolver)
+	peerAddresses := make(map[int32]string)
+	for _, id := range peerIDs {
+		// Peers tell branches that join the cluster where this one is
+		peerAddresses[id], _ = resolver.Resolve(ctx, id)
+	}
+	cancel()
+
+	// Register peers and establish connections between branches, before any
+	// branch starts so that Raft elections see the whole cluster
 	for id, server := range branchServers {
-		for peerID, client := range branchClients {
-			if id != peerID {
-				server.RegisterPeer(peerID, client)
+		for peerID, address := range peerAddresses {
+			if id == peerID {
+				continue
+			}
+			client, ok := branchClients[peerID]
+			if !ok {
+				client, err = createBranchClient(branch_service.BranchTarget(peerID), grpc.WithResolvers(builder), branch_service.BranchDialOption(server.TLS, peerID))
+				if err != nil {
+					log.Fatalf("Error creating a branch client for branch %d: %v", peerID, err)
+				}
+				if server.TLS == nil {
+					branchClients[peerID] = client
+				}
 			}
+			server.RegisterPeer(peerID, address, client)
 		}
 	}
 
-	// Block to keep the servers running
-	select {}
+	var ready []readyBranch
+	for _, data := range branchData {
+		listening := startBranch(data, branchServers[data.Id])
+		if registry != nil {
+			host, port, _ := net.SplitHostPort(listening.Address)
+			portNumber, _ := strconv.Atoi(port)
+			if err := registry.Register(branch_service.ClusterBranch{ID: data.Id, Host: host, Port: int32(portNumber)}); err != nil {
+				log.Fatalf("Error registering branch %d: %v", data.Id, err)
+			}
+		}
+		ready = append(ready, listening)
+	}
+	reportReady(ready, *readyFile)
+
+	// Keep the servers running until the process is told to stop
+	stopOnSignal(signals, branchServers, *stopTimeout)
+	if *readyFile != "" {
+		os.Remove(*readyFile)
+	}
+	if registry != nil {
+		for id := range branchServers {
+			if err := registry.Deregister(id); err != nil {
+				log.Printf("Error deregistering branch %d: %v", id, err)
+			}
+		}
+	}
 }